@@ -15,13 +15,16 @@ import (
 )
 
 type checkCommandFlags struct {
-	AuditPlan              []string `json:"auditPlan"`
-	CatalogSource          string   `json:"catalogsource"`
-	CatalogSourceNamespace string   `json:"catalogsourcenamespace"`
-	Packages               []string `json:"packages"`
-	AllInstallModes        bool     `json:"allInstallModes"`
-	ExtraCRDirectory       string   `json:"extraCRDirectory"`
-	DetailedReports        bool     `json:"detailedReports"`
+	AuditPlan               []string `json:"auditPlan"`
+	CatalogSource           string   `json:"catalogsource"`
+	CatalogSourceNamespace  string   `json:"catalogsourcenamespace"`
+	Packages                []string `json:"packages"`
+	AllInstallModes         bool     `json:"allInstallModes"`
+	ExtraCRDirectory        string   `json:"extraCRDirectory"`
+	OperandChangesDirectory string   `json:"operandChangesDirectory"`
+	DetailedReports         bool     `json:"detailedReports"`
+	RestrictedPodSecurity   bool     `json:"restrictedPodSecurity"`
+	LeastPrivilege          bool     `json:"leastPrivilege"`
 }
 
 var checkflags checkCommandFlags
@@ -52,7 +55,13 @@ and/or users.`,
 	flags.BoolVar(&checkflags.AllInstallModes, "all-installmodes", false, "when set, all install modes supported by an operator will be tested")
 	flags.StringVar(&checkflags.ExtraCRDirectory, "extra-cr-directory", "",
 		"directory containing the additional Custom Resources to be deployed by the OperandInstall audit. The manifest files should be located in subdirectories named after the packages they are corresponding to.")
+	flags.StringVar(&checkflags.OperandChangesDirectory, "operand-changes-directory", "",
+		"directory containing partial Custom Resources applied to the operands by the OperandReconfiguration audit. The manifest files should be located in subdirectories named after the packages they are corresponding to.")
 	flags.BoolVar(&checkflags.DetailedReports, "detailed-reports", false, "when set, a debug report will be created with events and logs for the tests being run")
+	flags.BoolVar(&checkflags.RestrictedPodSecurity, "restricted-pod-security", false,
+		"when set, the namespaces created for the operator are labeled to enforce the restricted Pod Security Admission profile before the operator is installed")
+	flags.BoolVar(&checkflags.LeastPrivilege, "least-privilege", false,
+		"when set, the OperatorGroup is scoped to a ServiceAccount with namespace-only permissions so that OLM installs the operator without cluster-admin privileges")
 
 	return cmd
 }
@@ -83,10 +92,13 @@ func runAudits(ctx context.Context, kubeconfig *rest.Config, client operator.Cli
 		capability.WithAllInstallModes(checkflags.AllInstallModes),
 		capability.WithClient(client),
 		capability.WithExtraCRDirectory(checkflags.ExtraCRDirectory),
+		capability.WithOperandChangesDirectory(checkflags.OperandChangesDirectory),
 		capability.WithFilesystem(fs),
 		capability.WithTimeout(time.Minute),
 		capability.WithReportWriter(reportWriter),
 		capability.WithDetailedReports(checkflags.DetailedReports),
+		capability.WithRestrictedPodSecurity(checkflags.RestrictedPodSecurity),
+		capability.WithLeastPrivilege(checkflags.LeastPrivilege),
 	); err != nil {
 		return err
 	}
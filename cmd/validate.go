@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func validateCmd() *cobra.Command {
+	// Run is empty. Otherwise, on an error, it would not be marked
+	// as Runnable, which would not print out the usage/help.
+	cmd := cobra.Command{
+		Use:   "validate",
+		Short: "Validate commands",
+		Long:  "Commands that validate operator artifacts without a cluster",
+	}
+
+	cmd.AddCommand(validateBundleCmd())
+
+	return &cmd
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/opdev/opcap/internal/bundle"
+	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
+
+	"github.com/spf13/cobra"
+)
+
+func validateBundleCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "bundle <bundle directory>",
+		Short: "Validate the ALM examples of a bundle",
+		Long: `Validates each example of the CSV alm-examples annotation against the OpenAPI v3 schema
+of the matching CRD in the manifests directory of the bundle, reporting unknown fields,
+missing required fields and apiVersion or kind mismatches.`,
+		Example: "opcap validate bundle ./operators/my-operator/1.0.0",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// invalid examples are reported, usage is only printed for wrong arguments
+			cmd.SilenceUsage = true
+			return validateBundle(args[0], cmd.OutOrStdout())
+		},
+	}
+
+	return &cmd
+}
+
+func validateBundle(bundleDir string, out io.Writer) error {
+	csv, crds, err := bundle.ReadBundleManifests(bundleDir)
+	if err != nil {
+		return err
+	}
+
+	packageName, err := bundle.PackageName(bundleDir)
+	if err != nil {
+		return err
+	}
+
+	validations, err := bundle.ValidateAlmExamples(csv, crds)
+	if err != nil {
+		return err
+	}
+
+	err = report.AlmExampleValidationTextReport(out, report.TemplateData{
+		Subscription:         operator.SubscriptionData{Package: packageName},
+		AlmExampleValidation: validations,
+	})
+	if err != nil {
+		return fmt.Errorf("could not generate ALM example validation text report: %v", err)
+	}
+
+	invalid := 0
+	for _, validation := range validations {
+		if len(validation.Errors) > 0 {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d ALM examples are invalid", invalid, len(validations))
+	}
+
+	return nil
+}
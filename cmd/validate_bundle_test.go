@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate Bundle Cmd", func() {
+	When("Calling opcap validate bundle", func() {
+		It("should succeed when every ALM example is valid", func() {
+			out, err := executeCommand(validateBundleCmd(), "../internal/bundle/testdata/operators/acc-operator/21.12.60")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("AstraControlCenter/astra (astra.netapp.io/v1): valid"))
+		})
+		It("should fail and report the errors of invalid ALM examples", func() {
+			out, err := executeCommand(validateBundleCmd(), "../internal/bundle/testdata/operators/acc-operator/21.10.7")
+			Expect(err).To(MatchError("1 of 1 ALM examples are invalid"))
+			Expect(out).To(ContainSubstring("spec.astraVersion: Invalid value"))
+		})
+		It("should fail without a bundle directory", func() {
+			_, err := executeCommand(validateBundleCmd())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,207 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ReadBundleManifests decodes the ClusterServiceVersion and the CustomResourceDefinitions in the manifests
+// directory of a bundle
+func ReadBundleManifests(bundleDir string) (*operatorv1alpha1.ClusterServiceVersion, []apiextensionsv1.CustomResourceDefinition, error) {
+	manifestsDir := filepath.Join(bundleDir, "manifests")
+	files, err := os.ReadDir(manifestsDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read bundle manifests: %s", err)
+	}
+
+	var csv *operatorv1alpha1.ClusterServiceVersion
+	crds := []apiextensionsv1.CustomResourceDefinition{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".yaml") && !strings.HasSuffix(f.Name(), ".yml") && !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(manifestsDir, f.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		typeMeta := metav1.TypeMeta{}
+		if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+			return nil, nil, fmt.Errorf("unable to decode %s: %s", f.Name(), err)
+		}
+
+		switch typeMeta.Kind {
+		case "ClusterServiceVersion":
+			csv = &operatorv1alpha1.ClusterServiceVersion{}
+			if err := yaml.Unmarshal(data, csv); err != nil {
+				return nil, nil, fmt.Errorf("unable to decode %s: %s", f.Name(), err)
+			}
+
+		case "CustomResourceDefinition":
+			// apiextensions.k8s.io/v1beta1 CRDs are not served since Kubernetes 1.22
+			if typeMeta.APIVersion != apiextensionsv1.SchemeGroupVersion.String() {
+				return nil, nil, fmt.Errorf("unsupported CustomResourceDefinition version in %s: %s", f.Name(), typeMeta.APIVersion)
+			}
+			crd := apiextensionsv1.CustomResourceDefinition{}
+			if err := yaml.Unmarshal(data, &crd); err != nil {
+				return nil, nil, fmt.Errorf("unable to decode %s: %s", f.Name(), err)
+			}
+			crds = append(crds, crd)
+		}
+	}
+
+	if csv == nil {
+		return nil, nil, fmt.Errorf("no ClusterServiceVersion found in %s", manifestsDir)
+	}
+
+	return csv, crds, nil
+}
+
+// PackageName returns the package of a bundle from its metadata annotations
+func PackageName(bundleDir string) (string, error) {
+	annotations, err := getAnnotations(filepath.Join(bundleDir, "metadata", "annotations.yaml"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get metadata annotations for bundle: %s", err)
+	}
+	return annotations["operators.operatorframework.io.bundle.package.v1"], nil
+}
+
+// AlmExamples decodes the alm-examples annotation of a CSV
+func AlmExamples(csv *operatorv1alpha1.ClusterServiceVersion) ([]map[string]interface{}, error) {
+	almExamples := csv.Annotations["alm-examples"]
+	if almExamples == "" {
+		return nil, nil
+	}
+
+	var almList []map[string]interface{}
+	if err := yaml.Unmarshal([]byte(almExamples), &almList); err != nil {
+		return nil, fmt.Errorf("unable to decode alm-examples of %s: %s", csv.Name, err)
+	}
+	return almList, nil
+}
+
+// findCRD returns the CRD defining kind in group
+func findCRD(crds []apiextensionsv1.CustomResourceDefinition, group, kind string) *apiextensionsv1.CustomResourceDefinition {
+	for i, crd := range crds {
+		if crd.Spec.Group == group && crd.Spec.Names.Kind == kind {
+			return &crds[i]
+		}
+	}
+	return nil
+}
+
+// validateAgainstSchema reports the unknown fields of example and the violations of the version schema
+func validateAgainstSchema(example map[string]interface{}, openAPIV3Schema *apiextensionsv1.JSONSchemaProps) ([]string, error) {
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(openAPIV3Schema, internal, nil); err != nil {
+		return nil, err
+	}
+	structural, err := schema.NewStructural(internal)
+	if err != nil {
+		return nil, fmt.Errorf("CRD schema is not structural: %v", err)
+	}
+
+	// pruning removes the unknown fields, so it runs on a copy of the example
+	data, err := json.Marshal(example)
+	if err != nil {
+		return nil, err
+	}
+	var pruned interface{}
+	if err := json.Unmarshal(data, &pruned); err != nil {
+		return nil, err
+	}
+
+	errs := []string{}
+	for _, path := range pruning.PruneWithOptions(pruned, structural, true, pruning.PruneOptions{ReturnPruned: true}) {
+		errs = append(errs, fmt.Sprintf("%s: unknown field", path))
+	}
+
+	validator, _, err := validation.NewSchemaValidator(&apiextensions.CustomResourceValidation{OpenAPIV3Schema: internal})
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range validation.ValidateCustomResource(nil, example, validator) {
+		errs = append(errs, err.Error())
+	}
+
+	return errs, nil
+}
+
+// validateAlmExample checks that a CRD in crds serves the apiVersion and kind of example, then validates example
+// against the schema of that version
+func validateAlmExample(example map[string]interface{}, crds []apiextensionsv1.CustomResourceDefinition) report.AlmExampleValidation {
+	obj := unstructured.Unstructured{Object: example}
+	gvk := obj.GroupVersionKind()
+	result := report.AlmExampleValidation{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Name:       obj.GetName(),
+	}
+
+	if gvk.Kind == "" || gvk.Version == "" {
+		result.Errors = append(result.Errors, "apiVersion and kind are required")
+		return result
+	}
+
+	crd := findCRD(crds, gvk.Group, gvk.Kind)
+	if crd == nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("no CustomResourceDefinition for kind %s in group %s", gvk.Kind, gvk.Group))
+		return result
+	}
+	result.CRD = crd.Name
+
+	var version *apiextensionsv1.CustomResourceDefinitionVersion
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == gvk.Version {
+			version = &crd.Spec.Versions[i]
+		}
+	}
+	switch {
+	case version == nil:
+		result.Errors = append(result.Errors, fmt.Sprintf("version %s is not defined by %s", gvk.Version, crd.Name))
+		return result
+	case !version.Served:
+		result.Errors = append(result.Errors, fmt.Sprintf("version %s is not served by %s", gvk.Version, crd.Name))
+		return result
+	case version.Schema == nil || version.Schema.OpenAPIV3Schema == nil:
+		return result
+	}
+
+	errs, err := validateAgainstSchema(example, version.Schema.OpenAPIV3Schema)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("could not validate against version %s: %v", gvk.Version, err))
+		return result
+	}
+	result.Errors = append(result.Errors, errs...)
+
+	return result
+}
+
+// ValidateAlmExamples validates each ALM example of the CSV against the OpenAPI v3 schema of the matching CRD
+func ValidateAlmExamples(csv *operatorv1alpha1.ClusterServiceVersion, crds []apiextensionsv1.CustomResourceDefinition) ([]report.AlmExampleValidation, error) {
+	examples, err := AlmExamples(csv)
+	if err != nil {
+		return nil, err
+	}
+
+	validations := []report.AlmExampleValidation{}
+	for _, example := range examples {
+		validations = append(validations, validateAlmExample(example, crds))
+	}
+	return validations, nil
+}
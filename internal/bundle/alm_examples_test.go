@@ -0,0 +1,78 @@
+package bundle
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+var _ = Describe("ALM examples", func() {
+	When("reading the manifests of a bundle", func() {
+		It("should decode the CSV and the CRDs", func() {
+			csv, crds, err := ReadBundleManifests("testdata/operators/acc-operator/21.12.60")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(csv.Name).To(Equal("acc-operator.v21.12.60"))
+			Expect(crds).To(HaveLen(1))
+			Expect(crds[0].Name).To(Equal("astracontrolcenters.astra.netapp.io"))
+		})
+		It("should fail without a manifests directory", func() {
+			_, _, err := ReadBundleManifests("testdata/operators/acc-operator")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	When("validating the ALM examples of a bundle", func() {
+		var crds []apiextensionsv1.CustomResourceDefinition
+
+		BeforeEach(func() {
+			var err error
+			_, crds, err = ReadBundleManifests("testdata/operators/acc-operator/21.12.60")
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should accept valid examples", func() {
+			csv, _, err := ReadBundleManifests("testdata/operators/acc-operator/21.12.60")
+			Expect(err).ToNot(HaveOccurred())
+
+			validations, err := ValidateAlmExamples(csv, crds)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(validations).To(HaveLen(1))
+			Expect(validations[0].CRD).To(Equal("astracontrolcenters.astra.netapp.io"))
+			Expect(validations[0].Errors).To(BeEmpty())
+		})
+		It("should report values not matching the schema", func() {
+			csv, olderCRDs, err := ReadBundleManifests("testdata/operators/acc-operator/21.10.7")
+			Expect(err).ToNot(HaveOccurred())
+
+			validations, err := ValidateAlmExamples(csv, olderCRDs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(validations[0].Errors).To(ConsistOf(ContainSubstring("spec.astraVersion: Invalid value")))
+		})
+		It("should report unknown and missing required fields", func() {
+			example := map[string]interface{}{
+				"apiVersion": "astra.netapp.io/v1",
+				"kind":       "AstraControlCenter",
+				"metadata":   map[string]interface{}{"name": "astra"},
+				"spec": map[string]interface{}{
+					"accountName":  "Example",
+					"astraAddress": "astra.example.com",
+					"astraVersion": "21.12.60",
+					"unknownField": true,
+				},
+			}
+
+			validation := validateAlmExample(example, crds)
+			Expect(validation.Errors).To(ContainElement("spec.unknownField: unknown field"))
+			Expect(validation.Errors).To(ContainElement("spec.email: Required value"))
+		})
+		It("should report kind and version mismatches", func() {
+			validation := validateAlmExample(map[string]interface{}{"apiVersion": "astra.netapp.io/v2", "kind": "AstraControlCenter"}, crds)
+			Expect(validation.Errors).To(Equal([]string{"version v2 is not defined by astracontrolcenters.astra.netapp.io"}))
+
+			validation = validateAlmExample(map[string]interface{}{"apiVersion": "astra.netapp.io/v1", "kind": "Other"}, crds)
+			Expect(validation.Errors).To(Equal([]string{"no CustomResourceDefinition for kind Other in group astra.netapp.io"}))
+
+			validation = validateAlmExample(map[string]interface{}{"metadata": map[string]interface{}{"name": "test"}}, crds)
+			Expect(validation.Errors).To(Equal([]string{"apiVersion and kind are required"}))
+		})
+	})
+})
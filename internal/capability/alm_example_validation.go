@@ -0,0 +1,44 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opdev/opcap/internal/bundle"
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// almExampleValidation validates the ALM examples of the operator's CSV against the OpenAPI v3 schemas of the
+// installed CRDs, without creating any operand
+func almExampleValidation(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("validating ALM examples for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+
+		crds := &apiextensionsv1.CustomResourceDefinitionList{}
+		if err := options.client.ListCRDs(ctx, crds); err != nil {
+			return fmt.Errorf("could not list CRDs: %v", err)
+		}
+
+		validations, err := bundle.ValidateAlmExamples(csv, crds.Items)
+		if err != nil {
+			return fmt.Errorf("could not validate ALM examples: %v", err)
+		}
+
+		return writeReports(&options, "alm_example_validation", report.TemplateData{
+			AlmExampleValidation: validations,
+		}, report.AlmExampleValidationJsonReport, report.AlmExampleValidationTextReport)
+	}, noCleanup
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/opdev/opcap/internal/logger"
 	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
 	"github.com/spf13/afero"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -31,7 +34,7 @@ type capAudit struct {
 	// subscription holds the data to install an operator via OLM
 	subscription operator.SubscriptionData
 
-	// Cluster CSV for current operator under test
+	// Cluster CSV for current operator under test, as installed by OperatorInstall or OperatorUpgrade
 	csv operatorv1alpha1.ClusterServiceVersion
 
 	// How much time to wait for a CSV before timeout
@@ -51,6 +54,9 @@ type capAudit struct {
 	// Operands stores a list of unstructured custom resources that were created at the API level
 	// This data is used for further analysis on statuses, conditions and other patterns
 	operands []unstructured.Unstructured
+
+	// OperandChanges stores partial CR manifests applied to operands by the OperandReconfiguration audit
+	operandChanges []map[string]interface{}
 }
 
 func generateNamespace(packageName string, installMode string) string {
@@ -118,6 +124,17 @@ func getTargetNamespaces(s operator.SubscriptionData, namespace string) []string
 	return []string{}
 }
 
+// auditNamespaces returns the operator's own namespace and its target namespaces
+func auditNamespaces(options *auditOptions) []string {
+	namespaces := []string{options.namespace}
+	for _, ns := range options.operatorGroupData.TargetNamespaces {
+		if ns != options.namespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 // WithSubscription adds a subscription object to the audit
 func withSubscription(subscription *operator.SubscriptionData) auditOption {
 	return func(options *auditOptions) error {
@@ -186,6 +203,14 @@ func withCustomResources(customResources []map[string]interface{}) auditOption {
 	}
 }
 
+// withOperandChanges adds the spec changes applied by the OperandReconfiguration audit
+func withOperandChanges(operandChanges []map[string]interface{}) auditOption {
+	return func(options *auditOptions) error {
+		options.operandChanges = operandChanges
+		return nil
+	}
+}
+
 // withFilesystem adds a filesystem to be used for writing files
 func withFilesystem(fs afero.Fs) auditOption {
 	return func(options *auditOptions) error {
@@ -205,6 +230,28 @@ func withReportWriter(w io.Writer) auditOption {
 	}
 }
 
+// withOperands adds the list of operands shared by all audits in an audit plan
+func withOperands(operands *[]unstructured.Unstructured) auditOption {
+	return func(options *auditOptions) error {
+		if operands == nil {
+			return fmt.Errorf("operands cannot be nil")
+		}
+		options.operands = operands
+		return nil
+	}
+}
+
+// withInstalledCSV adds the CSV shared by all audits in an audit plan, set once the operator is installed
+func withInstalledCSV(csv *operatorv1alpha1.ClusterServiceVersion) auditOption {
+	return func(options *auditOptions) error {
+		if csv == nil {
+			return fmt.Errorf("installed CSV cannot be nil")
+		}
+		options.installedCSV = csv
+		return nil
+	}
+}
+
 func withDetailedReports(detailedReports bool) auditOption {
 	return func(options *auditOptions) error {
 		options.detailedReports = detailedReports
@@ -212,13 +259,136 @@ func withDetailedReports(detailedReports bool) auditOption {
 	}
 }
 
+func withRestrictedPodSecurity(restrictedPodSecurity bool) auditOption {
+	return func(options *auditOptions) error {
+		options.restrictedPodSecurity = restrictedPodSecurity
+		return nil
+	}
+}
+
+func withLeastPrivilege(leastPrivilege bool) auditOption {
+	return func(options *auditOptions) error {
+		options.leastPrivilege = leastPrivilege
+		return nil
+	}
+}
+
+// noCleanup is the cleanup function of audits that only observe the cluster
+func noCleanup(_ context.Context) error {
+	return nil
+}
+
+// applyOptions builds the options of an audit from opts
+func applyOptions(opts []auditOption) (auditOptions, error) {
+	var options auditOptions
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return options, fmt.Errorf("option failed: %v", err)
+		}
+	}
+	return options, nil
+}
+
+// failed returns an audit or cleanup function that only returns err
+func failed(err error) func(context.Context) error {
+	return func(_ context.Context) error {
+		return err
+	}
+}
+
+// requireOperands tells if OperandInstall created operands for the audit to check, logging that it exits otherwise
+func requireOperands(options *auditOptions, audit string) bool {
+	if len(*options.operands) == 0 {
+		logger.Infow(fmt.Sprintf("exiting %s since no operands were created by OperandInstall", audit))
+		return false
+	}
+	return true
+}
+
+// currentCSV gets the CSV of the operator under test once it completed
+func currentCSV(ctx context.Context, options *auditOptions) (*operatorv1alpha1.ClusterServiceVersion, error) {
+	csv, err := options.client.GetCompletedCsvWithTimeout(ctx, options.namespace, time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("could not get CSV: %v", err)
+	}
+	return csv, nil
+}
+
+// writeReports appends the JSON report of an audit to <name>_report.json and writes its text report to the report
+// writer. The OpenShift version and the subscription are filled in data from options.
+func writeReports(options *auditOptions, name string, data report.TemplateData, jsonReport, textReport func(io.Writer, report.TemplateData) error) error {
+	file, err := options.fs.OpenFile(name+"_report.json", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data.OcpVersion = options.ocpVersion
+	data.Subscription = *options.subscription
+
+	if err := jsonReport(file, data); err != nil {
+		return fmt.Errorf("could not generate %s JSON report: %v", strings.ReplaceAll(name, "_", " "), err)
+	}
+	if err := textReport(options.reportWriter, data); err != nil {
+		return fmt.Errorf("could not generate %s text report: %v", strings.ReplaceAll(name, "_", " "), err)
+	}
+
+	return nil
+}
+
 // New returns a function corresponding to a passed in audit plan
 func newAudit(ctx context.Context, auditType string, opts ...auditOption) (auditFn, auditCleanupFn) {
 	switch strings.ToLower(auditType) {
 	case "operatorinstall":
 		return operatorInstall(ctx, opts...)
+	case "operatoruninstall":
+		return operatorUninstall(ctx, opts...)
 	case "operandinstall":
 		return operandInstall(ctx, opts...)
+	case "operatorupgrade":
+		return operatorUpgrade(ctx, opts...)
+	case "operandhealth":
+		return operandHealth(ctx, opts...)
+	case "operandstatus":
+		return operandStatus(ctx, opts...)
+	case "operandreconfiguration":
+		return operandReconfiguration(ctx, opts...)
+	case "operandselfhealing":
+		return operandSelfHealing(ctx, opts...)
+	case "operandscaling":
+		return operandScaling(ctx, opts...)
+	case "podsecurity":
+		return podSecurity(ctx, opts...)
+	case "containerresources":
+		return containerResources(ctx, opts...)
+	case "operandhighavailability":
+		return operandHighAvailability(ctx, opts...)
+	case "operanddisruption":
+		return operandDisruption(ctx, opts...)
+	case "monitoring":
+		return monitoring(ctx, opts...)
+	case "metricsendpoint":
+		return metricsEndpoint(ctx, opts...)
+	case "operandevents":
+		return operandEvents(ctx, opts...)
+	case "operanddeletion":
+		return operandDeletion(ctx, opts...)
+	case "watchscope":
+		return watchScope(ctx, opts...)
+	case "namespaceadmin":
+		return namespaceAdmin(ctx, opts...)
+	case "crdquality":
+		return crdQuality(ctx, opts...)
+	case "almexamplevalidation":
+		return almExampleValidation(ctx, opts...)
+	case "webhooks":
+		return webhooks(ctx, opts...)
+	case "crdconversion":
+		return crdConversion(ctx, opts...)
+	case "operatorrestart":
+		return operatorRestart(ctx, opts...)
+	case "leaderelection":
+		return leaderElection(ctx, opts...)
 	case "fakeplan":
 		return func(ctx context.Context) error { return nil }, func(ctx context.Context) error { return nil }
 	}
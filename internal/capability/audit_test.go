@@ -1,14 +1,18 @@
 package capability
 
 import (
+	"bytes"
 	"context"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/spf13/afero"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -49,4 +53,42 @@ var _ = Describe("Audit tests", func() {
 			Expect(len(audit.namespace)).To(Equal(63))
 		})
 	})
+	Context("sharing audit steps", func() {
+		It("should apply the options and report the failing one", func() {
+			options, err := applyOptions([]auditOption{withNamespace("testns"), withOcpVersion("4.11")})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options.namespace).To(Equal("testns"))
+			Expect(options.ocpVersion).To(Equal("4.11"))
+
+			_, err = applyOptions([]auditOption{withNamespace("")})
+			Expect(err).To(MatchError("option failed: namespace cannot be empty"))
+			Expect(failed(err)(context.TODO())).To(MatchError(err))
+		})
+		It("should only require operands once OperandInstall created some", func() {
+			operands := []unstructured.Unstructured{}
+			options := &auditOptions{operands: &operands}
+			Expect(requireOperands(options, "OperandHealth")).To(BeFalse())
+
+			operands = append(operands, newOwnedObject("Owner", "operand", "operand-uid", ""))
+			Expect(requireOperands(options, "OperandHealth")).To(BeTrue())
+		})
+		It("should write the JSON and text reports with the subscription", func() {
+			var w bytes.Buffer
+			options := &auditOptions{
+				fs:           afero.NewMemMapFs(),
+				reportWriter: &w,
+				ocpVersion:   "4.11",
+				subscription: &operator.SubscriptionData{Package: "testpackage", InstallModeType: v1alpha1.InstallModeTypeAllNamespaces},
+			}
+			data := report.TemplateData{OperatorUpgrade: report.OperatorUpgrade{FromVersion: "1.1.0", ToVersion: "1.2.0"}}
+
+			Expect(writeReports(options, "operator_upgrade", data, report.OperatorUpgradeJsonReport, report.OperatorUpgradeTextReport)).To(Succeed())
+			Expect(writeReports(options, "operator_upgrade", data, report.OperatorUpgradeJsonReport, report.OperatorUpgradeTextReport)).To(Succeed())
+			Expect(w.String()).To(ContainSubstring("OpenShift Version: 4.11"))
+
+			contents, err := afero.ReadFile(options.fs, "operator_upgrade_report.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes.Count(contents, []byte("testpackage"))).To(Equal(2))
+		})
+	})
 })
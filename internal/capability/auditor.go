@@ -19,7 +19,17 @@ import (
 type customResources = map[string][]map[string]interface{}
 
 // ExtraCRDirectory scans the provided directory and populates the extraCustomResources field.
-// Is is expected that the extraCRDirectory posesses subdirectories. Manifest files are present in each subdirectory.
+func extraCRDirectory(ctx context.Context, options *auditorOptions) (customResources, error) {
+	return manifestDirectory(ctx, options.fs, options.extraCustomResources)
+}
+
+// OperandChangesDirectory scans the provided directory for the spec changes applied by the OperandReconfiguration audit.
+func operandChangesDirectory(ctx context.Context, options *auditorOptions) (customResources, error) {
+	return manifestDirectory(ctx, options.fs, options.operandChanges)
+}
+
+// ManifestDirectory scans the provided directory and maps packages to the manifests found in it.
+// Is is expected that the directory posesses subdirectories. Manifest files are present in each subdirectory.
 // The name of the subdirectory is used to determine which package the manifest files are corresponding to.
 // The resulting structure would be:
 // custom_resources_directory/
@@ -31,16 +41,16 @@ type customResources = map[string][]map[string]interface{}
 //	   ├── manifest_file1.json
 //
 //     └── manifest_file2.yaml
-func extraCRDirectory(ctx context.Context, options *auditorOptions) (customResources, error) {
-	logger.Debugw("scaning for extra Custom Resources", "extra CR directory", options.extraCustomResources)
+func manifestDirectory(ctx context.Context, filesystem afero.Fs, directory string) (customResources, error) {
+	logger.Debugw("scaning for Custom Resources", "directory", directory)
 	extraCustomResources := customResources{} // maps packages to a list of CR
 
-	extraCRDirectoryAbsolutePath, err := filepath.Abs(options.extraCustomResources)
+	extraCRDirectoryAbsolutePath, err := filepath.Abs(directory)
 	if err != nil {
-		return nil, fmt.Errorf("could not get absolute path from %s: %v", options.extraCustomResources, err)
+		return nil, fmt.Errorf("could not get absolute path from %s: %v", directory, err)
 	}
 
-	err = afero.Walk(options.fs, extraCRDirectoryAbsolutePath, func(path string, d fs.FileInfo, err error) error {
+	err = afero.Walk(filesystem, extraCRDirectoryAbsolutePath, func(path string, d fs.FileInfo, err error) error {
 		if err != nil && path == extraCRDirectoryAbsolutePath {
 			// Error reading the root directory, exit and return the error
 			return err
@@ -50,7 +60,7 @@ func extraCRDirectory(ctx context.Context, options *auditorOptions) (customResou
 			manifestFilePath := path
 			// Checking that the manifest file is placed in a subdirectory
 			if len(strings.Split(manifestFilePath, "/")) != len(strings.Split(extraCRDirectoryAbsolutePath, "/"))+2 {
-				logger.Errorf("Error handling manifest file %s. File should be placed in a subdirectory of %s", manifestFilePath, options.extraCustomResources)
+				logger.Errorf("Error handling manifest file %s. File should be placed in a subdirectory of %s", manifestFilePath, directory)
 				return nil // continue
 			}
 
@@ -60,7 +70,7 @@ func extraCRDirectory(ctx context.Context, options *auditorOptions) (customResou
 			logger.Debugw("adding Custom Resource", "source manifest file", manifestFilePath, "package", packageName)
 
 			// Get manifest file content
-			manifestBytes, err := afero.ReadFile(options.fs, manifestFilePath)
+			manifestBytes, err := afero.ReadFile(filesystem, manifestFilePath)
 			if err != nil {
 				logger.Errorf("Error reading file %s: %v", manifestFilePath, err)
 				return nil // continue
@@ -92,14 +102,14 @@ func extraCRDirectory(ctx context.Context, options *auditorOptions) (customResou
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("could not read directory %s: %v", options.extraCustomResources, err)
+		return nil, fmt.Errorf("could not read directory %s: %v", directory, err)
 	}
 
 	return extraCustomResources, nil
 }
 
 // BuildWorkQueueByCatalog fills in the auditor workqueue with all package information found in a specific catalog
-func buildWorkQueueByCatalog(ctx context.Context, options *auditorOptions, extraCustomResources customResources) error {
+func buildWorkQueueByCatalog(ctx context.Context, options *auditorOptions, extraCustomResources customResources, operandChanges customResources) error {
 	// Getting subscription data form the package manifests available in the selected catalog
 	subscriptions, err := options.opCapClient.GetSubscriptionData(ctx, options.catalogSource, options.catalogSourceNamespace, options.packages)
 	if err != nil {
@@ -141,6 +151,9 @@ func buildWorkQueueByCatalog(ctx context.Context, options *auditorOptions, extra
 			return fmt.Errorf("could not build configuration for subscription: %s: %v", subscription.Name, err)
 		}
 
+		// Get spec changes for the OperandReconfiguration audit, if any
+		capAudit.operandChanges = operandChanges[subscription.Package]
+
 		// load workqueue with capAudit
 		options.workQueue <- *capAudit
 	}
@@ -187,7 +200,16 @@ func RunAudits(ctx context.Context, opts ...auditorOption) error {
 		}
 	}
 
-	err := buildWorkQueueByCatalog(ctx, &options, extraCustomResources)
+	var operandChanges customResources
+	if options.operandChanges != "" {
+		var err error
+		operandChanges, err = operandChangesDirectory(ctx, &options)
+		if err != nil {
+			return fmt.Errorf("could not read operand changes directory: %v", err)
+		}
+	}
+
+	err := buildWorkQueueByCatalog(ctx, &options, extraCustomResources, operandChanges)
 	if err != nil {
 		return fmt.Errorf("unable to build workqueue: %v", err)
 	}
@@ -207,9 +229,14 @@ func RunAudits(ctx context.Context, opts ...auditorOption) error {
 				withSubscription(&audit.subscription),
 				withTimeout(options.timeout),
 				withCustomResources(audit.customResources),
+				withOperands(&audit.operands),
+				withInstalledCSV(&audit.csv),
+				withOperandChanges(audit.operandChanges),
 				withFilesystem(options.fs),
 				withReportWriter(options.reportWriter),
 				withDetailedReports(options.detailedReports),
+				withRestrictedPodSecurity(options.restrictedPodSecurity),
+				withLeastPrivilege(options.leastPrivilege),
 			)
 			if auditFn == nil {
 				logger.Errorf("invalid audit plan specified: %s", function)
@@ -289,6 +316,13 @@ func WithExtraCRDirectory(extraCRDirectory string) auditorOption {
 	}
 }
 
+func WithOperandChangesDirectory(operandChangesDirectory string) auditorOption {
+	return func(options *auditorOptions) error {
+		options.operandChanges = operandChangesDirectory
+		return nil
+	}
+}
+
 func WithFilesystem(fs afero.Fs) auditorOption {
 	return func(options *auditorOptions) error {
 		if fs == nil {
@@ -322,3 +356,17 @@ func WithDetailedReports(detailedReports bool) auditorOption {
 		return nil
 	}
 }
+
+func WithRestrictedPodSecurity(restrictedPodSecurity bool) auditorOption {
+	return func(options *auditorOptions) error {
+		options.restrictedPodSecurity = restrictedPodSecurity
+		return nil
+	}
+}
+
+func WithLeastPrivilege(leastPrivilege bool) auditorOption {
+	return func(options *auditorOptions) error {
+		options.leastPrivilege = leastPrivilege
+		return nil
+	}
+}
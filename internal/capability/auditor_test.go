@@ -165,6 +165,33 @@ var _ = Describe("Auditor tests", func() {
 			})
 		})
 
+		Context("Restricted Pod Security", func() {
+			When("restricted pod security is enabled", func() {
+				It("should set restricted pod security correctly", func() {
+					Expect(WithRestrictedPodSecurity(true)(options)).To(Succeed())
+					Expect(options.restrictedPodSecurity).To(BeTrue())
+				})
+			})
+		})
+
+		Context("Least Privilege", func() {
+			When("least privilege is enabled", func() {
+				It("should set least privilege correctly", func() {
+					Expect(WithLeastPrivilege(true)(options)).To(Succeed())
+					Expect(options.leastPrivilege).To(BeTrue())
+				})
+			})
+		})
+
+		Context("Operand Changes Directory", func() {
+			When("operand changes directory is supplied", func() {
+				It("should set operand changes directory correctly", func() {
+					Expect(WithOperandChangesDirectory("/changesdir")(options)).To(Succeed())
+					Expect(options.operandChanges).To(Equal("/changesdir"))
+				})
+			})
+		})
+
 		Context("Filesystem", func() {
 			When("a filesystem is not supplied", func() {
 				It("should throw an error", func() {
@@ -0,0 +1,96 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// inspectContainerResources tells which resource requests, limits and probes are set on a container
+func inspectContainerResources(source, owner string, container corev1.Container) report.ContainerResources {
+	resources := report.ContainerResources{
+		Source:         source,
+		Owner:          owner,
+		Name:           container.Name,
+		CPURequest:     !container.Resources.Requests.Cpu().IsZero(),
+		MemoryRequest:  !container.Resources.Requests.Memory().IsZero(),
+		CPULimit:       !container.Resources.Limits.Cpu().IsZero(),
+		MemoryLimit:    !container.Resources.Limits.Memory().IsZero(),
+		LivenessProbe:  container.LivenessProbe != nil,
+		ReadinessProbe: container.ReadinessProbe != nil,
+	}
+
+	for _, setting := range []struct {
+		name string
+		set  bool
+	}{
+		{"cpu request", resources.CPURequest},
+		{"memory request", resources.MemoryRequest},
+		{"cpu limit", resources.CPULimit},
+		{"memory limit", resources.MemoryLimit},
+		{"liveness probe", resources.LivenessProbe},
+		{"readiness probe", resources.ReadinessProbe},
+	} {
+		if !setting.set {
+			resources.Missing = append(resources.Missing, setting.name)
+		}
+	}
+
+	return resources
+}
+
+// containerResources reports the resource requests, limits and probes of the containers in the operator
+// deployments declared by the CSV and in the pods owned by each operand created by OperandInstall
+func containerResources(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking container resources for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+
+		containers := []report.ContainerResources{}
+		for _, deployment := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+			for _, container := range deployment.Spec.Template.Spec.Containers {
+				containers = append(containers, inspectContainerResources("operator", "Deployment/"+deployment.Name, container))
+			}
+		}
+
+		for _, operand := range *options.operands {
+			owned, err := listOwnedResources(ctx, &options, operand.GetNamespace(), operand, workloadKinds)
+			if err != nil {
+				logger.Errorw("could not list operand pods", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+				continue
+			}
+			for _, obj := range owned {
+				if obj.GetKind() != "Pod" {
+					continue
+				}
+				var pod corev1.Pod
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+					return fmt.Errorf("could not read pod %s: %v", obj.GetName(), err)
+				}
+				for _, container := range pod.Spec.Containers {
+					owner := strings.Join([]string{operand.GetKind(), operand.GetName(), "Pod", pod.Name}, "/")
+					containers = append(containers, inspectContainerResources("operand", owner, container))
+				}
+			}
+		}
+
+		return writeReports(&options, "container_resources", report.TemplateData{
+			ContainerResources: containers,
+		}, report.ContainerResourcesJsonReport, report.ContainerResourcesTextReport)
+	}, noCleanup
+}
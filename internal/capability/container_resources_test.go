@@ -0,0 +1,54 @@
+package capability
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ = Describe("Container resources", func() {
+	When("all requests, limits and probes are set", func() {
+		It("should not report anything missing", func() {
+			container := corev1.Container{
+				Name: "manager",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("10m"),
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+				LivenessProbe:  &corev1.Probe{},
+				ReadinessProbe: &corev1.Probe{},
+			}
+
+			resources := inspectContainerResources("operator", "Deployment/test", container)
+			Expect(resources.Owner).To(Equal("Deployment/test"))
+			Expect(resources.CPURequest).To(BeTrue())
+			Expect(resources.MemoryLimit).To(BeTrue())
+			Expect(resources.Missing).To(BeEmpty())
+		})
+	})
+	When("only some settings are present", func() {
+		It("should report the missing ones", func() {
+			container := corev1.Container{
+				Name: "manager",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				},
+				ReadinessProbe: &corev1.Probe{},
+			}
+
+			resources := inspectContainerResources("operand", "testkind/testname/Pod/test", container)
+			Expect(resources.MemoryRequest).To(BeTrue())
+			Expect(resources.ReadinessProbe).To(BeTrue())
+			Expect(resources.Missing).To(Equal([]string{"cpu request", "cpu limit", "memory limit", "liveness probe"}))
+		})
+	})
+})
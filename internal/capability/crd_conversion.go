@@ -0,0 +1,248 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+)
+
+// flattenFields collects the leaf values of an object by path, leaving out the type and object metadata and the
+// status set by the operator
+func flattenFields(path string, value interface{}, fields map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if path == "" && (key == "apiVersion" || key == "kind" || key == "metadata" || key == "status") {
+				continue
+			}
+			flattenFields(strings.TrimPrefix(path+"."+key, "."), child, fields)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenFields(fmt.Sprintf("%s[%d]", path, i), child, fields)
+		}
+	default:
+		fields[path] = v
+	}
+}
+
+// compareFields returns the fields of before missing from after and the ones holding another value
+func compareFields(before, after map[string]interface{}) ([]string, []string) {
+	beforeFields, afterFields := map[string]interface{}{}, map[string]interface{}{}
+	flattenFields("", before, beforeFields)
+	flattenFields("", after, afterFields)
+
+	lost, changed := []string{}, []string{}
+	for path, value := range beforeFields {
+		afterValue, ok := afterFields[path]
+		switch {
+		case !ok:
+			lost = append(lost, path)
+		case !reflect.DeepEqual(value, afterValue):
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(lost)
+	sort.Strings(changed)
+
+	return lost, changed
+}
+
+// getInVersion reads an object through another served version of its CRD
+func getInVersion(ctx context.Context, options *auditOptions, operand unstructured.Unstructured, version string) (*unstructured.Unstructured, error) {
+	gvk := operand.GroupVersionKind()
+	gvk.Version = version
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// roundTripVersion reads an operand through another served version, writes it back unchanged in that version and
+// reads it again in its original version, reporting the fields lost or changed by the conversions
+func roundTripVersion(ctx context.Context, options *auditOptions, operand unstructured.Unstructured, version string) report.VersionRoundTrip {
+	roundTrip := report.VersionRoundTrip{Version: version}
+
+	var before *unstructured.Unstructured
+	step := "read as " + operand.GroupVersionKind().Version
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		// the operator may update the operand, so the baseline is read right before the round trip
+		before, err = getInVersion(ctx, options, operand, operand.GroupVersionKind().Version)
+		if err != nil {
+			return err
+		}
+		step = "read as " + version
+		obj, err := getInVersion(ctx, options, operand, version)
+		if err != nil {
+			return err
+		}
+		roundTrip.Read = true
+		step = "write as " + version
+		if err := options.client.UpdateUnstructured(ctx, obj); err != nil {
+			return err
+		}
+		roundTrip.Written = true
+		return nil
+	})
+	if err != nil {
+		roundTrip.Error = fmt.Sprintf("could not %s: %v", step, err)
+		return roundTrip
+	}
+
+	after, err := getInVersion(ctx, options, operand, operand.GroupVersionKind().Version)
+	if err != nil {
+		roundTrip.Error = fmt.Sprintf("could not read back as %s: %v", operand.GroupVersionKind().Version, err)
+		return roundTrip
+	}
+	roundTrip.LostFields, roundTrip.ChangedFields = compareFields(before.Object, after.Object)
+
+	return roundTrip
+}
+
+// servedVersions returns the names of the versions a CRD serves
+func servedVersions(crd apiextensionsv1.CustomResourceDefinition) []string {
+	versions := []string{}
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			versions = append(versions, version.Name)
+		}
+	}
+	return versions
+}
+
+// crdConversionPassed tells if every version could be read and written without losing or changing fields
+func crdConversionPassed(conversion report.CRDConversion) bool {
+	if conversion.Error != "" {
+		return false
+	}
+	for _, roundTrip := range conversion.Versions {
+		if roundTrip.Error != "" || len(roundTrip.LostFields) > 0 || len(roundTrip.ChangedFields) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// checkCRDConversion creates an ALM example in its version and round trips it through every other version the
+// CRD serves. The operand is removed afterwards.
+func checkCRDConversion(ctx context.Context, options *auditOptions, crd apiextensionsv1.CustomResourceDefinition, example map[string]interface{}) report.CRDConversion {
+	operand := unstructured.Unstructured{Object: example}
+	conversion := report.CRDConversion{
+		CRD:            crd.Name,
+		Kind:           crd.Spec.Names.Kind,
+		CreatedVersion: operand.GroupVersionKind().Version,
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			conversion.StorageVersion = version.Name
+		}
+	}
+
+	obj := operand.DeepCopy()
+	obj.SetName(strings.Join([]string{operand.GetName(), "opcap", "conversion"}, "-"))
+	if crd.Spec.Scope == apiextensionsv1.NamespaceScoped {
+		obj.SetNamespace(options.namespace)
+	}
+	if err := options.client.CreateUnstructured(ctx, obj); err != nil {
+		conversion.Error = fmt.Sprintf("could not create as %s: %v", conversion.CreatedVersion, err)
+		return conversion
+	}
+	defer func() {
+		if err := removeOperand(ctx, options, *obj); err != nil {
+			logger.Errorw("could not remove conversion operand", "error", err, "kind", obj.GetKind(), "name", obj.GetName())
+		}
+	}()
+
+	for _, version := range servedVersions(crd) {
+		if version == conversion.CreatedVersion {
+			continue
+		}
+		conversion.Versions = append(conversion.Versions, roundTripVersion(ctx, options, *obj, version))
+	}
+	conversion.Passed = crdConversionPassed(conversion)
+
+	return conversion
+}
+
+// crdConversion round trips the ALM examples through every served version of the multi-version CRDs owned by
+// the operator's CSV
+func crdConversion(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking CRD conversion for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+
+		crds := &apiextensionsv1.CustomResourceDefinitionList{}
+		if err := options.client.ListCRDs(ctx, crds); err != nil {
+			return fmt.Errorf("could not list CRDs: %v", err)
+		}
+		byName := map[string]apiextensionsv1.CustomResourceDefinition{}
+		for _, crd := range crds.Items {
+			byName[crd.Name] = crd
+		}
+
+		if err := extractAlmExamples(ctx, &options); err != nil {
+			logger.Errorf("could not get ALM Examples: %v", err)
+		}
+
+		conversions := []report.CRDConversion{}
+		checked := map[string]bool{}
+		for _, owned := range csv.Spec.CustomResourceDefinitions.Owned {
+			crd, ok := byName[owned.Name]
+			if !ok || checked[owned.Name] || len(servedVersions(crd)) < 2 {
+				continue
+			}
+			checked[owned.Name] = true
+
+			var example map[string]interface{}
+			for _, cr := range options.customResources {
+				gvk := (&unstructured.Unstructured{Object: cr}).GroupVersionKind()
+				if gvk.Group == crd.Spec.Group && gvk.Kind == crd.Spec.Names.Kind {
+					example = cr
+					break
+				}
+			}
+			if example == nil {
+				logger.Infow("skipping CRD conversion since no ALM example was found", "crd", crd.Name)
+				conversions = append(conversions, report.CRDConversion{
+					CRD:   crd.Name,
+					Kind:  crd.Spec.Names.Kind,
+					Error: "no ALM example",
+				})
+				continue
+			}
+
+			conversions = append(conversions, checkCRDConversion(ctx, &options, crd, example))
+		}
+
+		if len(conversions) == 0 {
+			logger.Infow("exiting CRDConversion since the CSV owns no CRD serving several versions")
+			return nil
+		}
+
+		return writeReports(&options, "crd_conversion", report.TemplateData{
+			CRDConversion: conversions,
+		}, report.CRDConversionJsonReport, report.CRDConversionTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,139 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// lossyConversionClient is an operator.Client serving ConfigMaps in an extra v2 version whose conversion drops
+// the data.dropped key
+type lossyConversionClient struct {
+	operator.Client
+}
+
+func (c lossyConversionClient) GetUnstructured(ctx context.Context, namespace, name string, obj *unstructured.Unstructured) error {
+	if obj.GroupVersionKind().Version != "v2" {
+		return c.Client.GetUnstructured(ctx, namespace, name, obj)
+	}
+	obj.SetAPIVersion("v1")
+	if err := c.Client.GetUnstructured(ctx, namespace, name, obj); err != nil {
+		return err
+	}
+	obj.SetAPIVersion("v2")
+	unstructured.RemoveNestedField(obj.Object, "data", "dropped")
+	return nil
+}
+
+func (c lossyConversionClient) UpdateUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
+	if obj.GroupVersionKind().Version == "v2" {
+		obj = obj.DeepCopy()
+		obj.SetAPIVersion("v1")
+	}
+	return c.Client.UpdateUnstructured(ctx, obj)
+}
+
+var _ = Describe("CRD conversion", func() {
+	When("comparing an object before and after a round trip", func() {
+		It("should report lost and changed fields", func() {
+			before := map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"metadata":   map[string]interface{}{"resourceVersion": "1"},
+				"spec": map[string]interface{}{
+					"size":  int64(3),
+					"name":  "test",
+					"ports": []interface{}{int64(80), int64(443)},
+				},
+				"status": map[string]interface{}{"ready": true},
+			}
+			after := map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"metadata":   map[string]interface{}{"resourceVersion": "2"},
+				"spec": map[string]interface{}{
+					"size":  int64(5),
+					"ports": []interface{}{int64(80)},
+					"extra": "defaulted",
+				},
+			}
+
+			lost, changed := compareFields(before, after)
+			Expect(lost).To(Equal([]string{"spec.name", "spec.ports[1]"}))
+			Expect(changed).To(Equal([]string{"spec.size"}))
+		})
+	})
+	When("round tripping an operand through another version", func() {
+		var options *auditOptions
+		var fakeClient operator.Client
+		var operand *unstructured.Unstructured
+
+		BeforeEach(func() {
+			operand = &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "operand", "namespace": "testns"},
+				"data":       map[string]interface{}{"kept": "a", "dropped": "b"},
+			}}
+			fakeClient = operator.NewFakeOpClient()
+			options = &auditOptions{client: lossyConversionClient{fakeClient}, namespace: "testns"}
+			Expect(options.client.CreateUnstructured(context.TODO(), operand)).To(Succeed())
+		})
+		It("should report the fields lost by the conversion", func() {
+			roundTrip := roundTripVersion(context.TODO(), options, *operand, "v2")
+			Expect(roundTrip.Error).To(BeEmpty())
+			Expect(roundTrip.Read).To(BeTrue())
+			Expect(roundTrip.Written).To(BeTrue())
+			Expect(roundTrip.LostFields).To(Equal([]string{"data.dropped"}))
+			Expect(roundTrip.ChangedFields).To(BeEmpty())
+		})
+		It("should report versions that can't be read", func() {
+			options.client = fakeClient
+			roundTrip := roundTripVersion(context.TODO(), options, *operand, "v2")
+			Expect(roundTrip.Read).To(BeFalse())
+			Expect(roundTrip.Error).To(HavePrefix("could not read as v2"))
+		})
+	})
+	When("checking a multi-version CRD", func() {
+		It("should create the example, round trip it through the other versions and remove it", func() {
+			client := lossyConversionClient{operator.NewFakeOpClient()}
+			options := &auditOptions{client: client, namespace: "testns"}
+			crd := apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "configmaps"},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "ConfigMap"},
+					Scope: apiextensionsv1.NamespaceScoped,
+					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true},
+						{Name: "v2", Served: true},
+						{Name: "v3"},
+					},
+				},
+			}
+			example := map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "example"},
+				"data":       map[string]interface{}{"dropped": "b"},
+			}
+
+			conversion := checkCRDConversion(context.TODO(), options, crd, example)
+			Expect(conversion.Error).To(BeEmpty())
+			Expect(conversion.StorageVersion).To(Equal("v1"))
+			Expect(conversion.CreatedVersion).To(Equal("v1"))
+			Expect(conversion.Versions).To(HaveLen(1))
+			Expect(conversion.Versions[0].Version).To(Equal("v2"))
+			Expect(conversion.Versions[0].LostFields).To(Equal([]string{"data.dropped"}))
+			Expect(conversion.Passed).To(BeFalse())
+
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion("v1")
+			obj.SetKind("ConfigMap")
+			Expect(client.GetUnstructured(context.TODO(), "testns", "example-opcap-conversion", obj)).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,184 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// structuralSchema validates that a CRD version schema is structural, the requirement for pruning, defaulting
+// and server-side apply
+func structuralSchema(version apiextensionsv1.CustomResourceDefinitionVersion) error {
+	if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+		return fmt.Errorf("no OpenAPI schema")
+	}
+
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema.OpenAPIV3Schema, internal, nil); err != nil {
+		return err
+	}
+	structural, err := schema.NewStructural(internal)
+	if err != nil {
+		return err
+	}
+	if errs := schema.ValidateStructural(field.NewPath("openAPIV3Schema"), structural); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	return nil
+}
+
+// undescribedFields returns the paths of the fields under props without a description
+func undescribedFields(path string, props apiextensionsv1.JSONSchemaProps) (fields int, undescribed []string) {
+	names := make([]string, 0, len(props.Properties))
+	for name := range props.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// metadata is described by the ObjectMeta schema of the API server
+		if path == "" && name == "metadata" {
+			continue
+		}
+		child := props.Properties[name]
+		childPath := strings.TrimPrefix(path+"."+name, ".")
+
+		fields++
+		if child.Description == "" {
+			undescribed = append(undescribed, childPath)
+		}
+		childFields, childUndescribed := undescribedFields(childPath, child)
+		fields += childFields
+		undescribed = append(undescribed, childUndescribed...)
+	}
+	if props.Items != nil && props.Items.Schema != nil {
+		itemFields, itemUndescribed := undescribedFields(path+"[]", *props.Items.Schema)
+		fields += itemFields
+		undescribed = append(undescribed, itemUndescribed...)
+	}
+
+	return fields, undescribed
+}
+
+// inspectCRD checks a CRD owned by the CSV for a structural schema on every version, a status subresource, field
+// descriptions, a single stored version, deprecated versions and a conversion strategy for multi-version CRDs
+func inspectCRD(crd apiextensionsv1.CustomResourceDefinition) report.CRDQuality {
+	quality := report.CRDQuality{
+		Name:              crd.Name,
+		Kind:              crd.Spec.Names.Kind,
+		Found:             true,
+		Structural:        true,
+		StatusSubresource: true,
+		StoredVersions:    crd.Status.StoredVersions,
+		Conversion:        string(apiextensionsv1.NoneConverter),
+	}
+	if crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy != "" {
+		quality.Conversion = string(crd.Spec.Conversion.Strategy)
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			quality.Versions = append(quality.Versions, version.Name)
+		}
+		if version.Deprecated {
+			quality.DeprecatedVersions = append(quality.DeprecatedVersions, version.Name)
+			if version.Storage {
+				quality.Findings = append(quality.Findings, fmt.Sprintf("deprecated version %s is the storage version", version.Name))
+			}
+		}
+		if err := structuralSchema(version); err != nil {
+			quality.Structural = false
+			quality.Findings = append(quality.Findings, fmt.Sprintf("version %s schema is not structural: %v", version.Name, err))
+		}
+		if version.Served && (version.Subresources == nil || version.Subresources.Status == nil) {
+			quality.StatusSubresource = false
+			quality.Findings = append(quality.Findings, fmt.Sprintf("version %s has no status subresource", version.Name))
+		}
+		if version.Storage && version.Schema != nil && version.Schema.OpenAPIV3Schema != nil {
+			fields, undescribed := undescribedFields("", *version.Schema.OpenAPIV3Schema)
+			quality.Fields = fields
+			quality.UndescribedFields = undescribed
+		}
+	}
+
+	if len(quality.UndescribedFields) > 0 {
+		quality.Findings = append(quality.Findings, fmt.Sprintf("%d of %d fields have no description", len(quality.UndescribedFields), quality.Fields))
+	}
+	if len(quality.StoredVersions) > 1 {
+		quality.Findings = append(quality.Findings, fmt.Sprintf("objects are stored in several versions: %s", strings.Join(quality.StoredVersions, ", ")))
+	}
+	if len(quality.Versions) > 1 && quality.Conversion == string(apiextensionsv1.NoneConverter) {
+		quality.Findings = append(quality.Findings, fmt.Sprintf("serves %d versions without a conversion webhook", len(quality.Versions)))
+	}
+
+	return quality
+}
+
+// checkOwnedCRDs inspects the CRDs owned by the CSV, reporting owned entries without a matching CRD in the cluster
+func checkOwnedCRDs(csv *operatorv1alpha1.ClusterServiceVersion, crds []apiextensionsv1.CustomResourceDefinition) []report.CRDQuality {
+	byName := map[string]apiextensionsv1.CustomResourceDefinition{}
+	for _, crd := range crds {
+		byName[crd.Name] = crd
+	}
+
+	qualities := []report.CRDQuality{}
+	checked := map[string]bool{}
+	for _, owned := range csv.Spec.CustomResourceDefinitions.Owned {
+		// CSVs list an owned CRD once per version
+		if checked[owned.Name] {
+			continue
+		}
+		checked[owned.Name] = true
+
+		crd, ok := byName[owned.Name]
+		if !ok {
+			qualities = append(qualities, report.CRDQuality{
+				Name:     owned.Name,
+				Kind:     owned.Kind,
+				Findings: []string{"no matching CustomResourceDefinition"},
+			})
+			continue
+		}
+		qualities = append(qualities, inspectCRD(crd))
+	}
+
+	return qualities
+}
+
+// crdQuality checks the CustomResourceDefinitions owned by the operator's CSV
+func crdQuality(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking CRD quality for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+
+		crds := &apiextensionsv1.CustomResourceDefinitionList{}
+		if err := options.client.ListCRDs(ctx, crds); err != nil {
+			return fmt.Errorf("could not list CRDs: %v", err)
+		}
+
+		qualities := checkOwnedCRDs(csv, crds.Items)
+
+		return writeReports(&options, "crd_quality", report.TemplateData{
+			CRDQuality: qualities,
+		}, report.CRDQualityJsonReport, report.CRDQualityTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,148 @@
+package capability
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCRDVersion(name string, storage bool, props apiextensionsv1.JSONSchemaProps) apiextensionsv1.CustomResourceDefinitionVersion {
+	return apiextensionsv1.CustomResourceDefinitionVersion{
+		Name:    name,
+		Served:  true,
+		Storage: storage,
+		Schema: &apiextensionsv1.CustomResourceValidation{
+			OpenAPIV3Schema: &props,
+		},
+		Subresources: &apiextensionsv1.CustomResourceSubresources{
+			Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+		},
+	}
+}
+
+func describedSchema() apiextensionsv1.JSONSchemaProps {
+	return apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"metadata": {Type: "object"},
+			"spec": {
+				Type:        "object",
+				Description: "desired state",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"replicas": {Type: "integer", Description: "number of replicas"},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("CRD quality", func() {
+	var crd apiextensionsv1.CustomResourceDefinition
+
+	BeforeEach(func() {
+		crd = apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "testkinds.example.com"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: "TestKind"},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{newCRDVersion("v1", true, describedSchema())},
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1"}},
+		}
+	})
+
+	When("the CRD follows every best practice", func() {
+		It("should not report findings", func() {
+			quality := inspectCRD(crd)
+			Expect(quality.Found).To(BeTrue())
+			Expect(quality.Structural).To(BeTrue())
+			Expect(quality.StatusSubresource).To(BeTrue())
+			Expect(quality.Fields).To(Equal(2))
+			Expect(quality.Conversion).To(Equal("None"))
+			Expect(quality.Findings).To(BeEmpty())
+		})
+	})
+	When("the schema is not structural", func() {
+		It("should report the version", func() {
+			crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"] = apiextensionsv1.JSONSchemaProps{Description: "untyped"}
+
+			quality := inspectCRD(crd)
+			Expect(quality.Structural).To(BeFalse())
+			Expect(quality.Findings).To(ContainElement(ContainSubstring("version v1 schema is not structural")))
+		})
+	})
+	When("fields have no description and there is no status subresource", func() {
+		It("should report them", func() {
+			crd.Spec.Versions[0] = newCRDVersion("v1", true, apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"spec": {
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"items": {
+								Type:  "array",
+								Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{"name": {Type: "string"}}}},
+							},
+						},
+					},
+				},
+			})
+			crd.Spec.Versions[0].Subresources = nil
+
+			quality := inspectCRD(crd)
+			Expect(quality.StatusSubresource).To(BeFalse())
+			Expect(quality.UndescribedFields).To(Equal([]string{"spec", "spec.items", "spec.items[].name"}))
+			Expect(quality.Findings).To(ConsistOf("version v1 has no status subresource", "3 of 3 fields have no description"))
+		})
+	})
+	When("several versions are served without conversion", func() {
+		It("should report the conversion, stored and deprecated versions", func() {
+			old := newCRDVersion("v1alpha1", false, describedSchema())
+			old.Deprecated = true
+			crd.Spec.Versions = append([]apiextensionsv1.CustomResourceDefinitionVersion{old}, crd.Spec.Versions...)
+			crd.Status.StoredVersions = []string{"v1alpha1", "v1"}
+
+			quality := inspectCRD(crd)
+			Expect(quality.Versions).To(Equal([]string{"v1alpha1", "v1"}))
+			Expect(quality.DeprecatedVersions).To(Equal([]string{"v1alpha1"}))
+			Expect(quality.Findings).To(ConsistOf(
+				"objects are stored in several versions: v1alpha1, v1",
+				"serves 2 versions without a conversion webhook",
+			))
+		})
+		It("should accept a conversion webhook", func() {
+			crd.Spec.Versions = append(crd.Spec.Versions, newCRDVersion("v2", false, describedSchema()))
+			crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.WebhookConverter}
+
+			quality := inspectCRD(crd)
+			Expect(quality.Conversion).To(Equal("Webhook"))
+			Expect(quality.Findings).To(BeEmpty())
+		})
+	})
+	When("a deprecated version is the storage version", func() {
+		It("should report it", func() {
+			crd.Spec.Versions[0].Deprecated = true
+
+			quality := inspectCRD(crd)
+			Expect(quality.Findings).To(ContainElement("deprecated version v1 is the storage version"))
+		})
+	})
+	When("checking the CRDs owned by a CSV", func() {
+		It("should report owned entries without a CRD once", func() {
+			csv := &operatorv1alpha1.ClusterServiceVersion{}
+			csv.Spec.CustomResourceDefinitions.Owned = []operatorv1alpha1.CRDDescription{
+				{Name: "testkinds.example.com", Version: "v1", Kind: "TestKind"},
+				{Name: "testkinds.example.com", Version: "v1alpha1", Kind: "TestKind"},
+				{Name: "missings.example.com", Version: "v1", Kind: "Missing"},
+			}
+
+			qualities := checkOwnedCRDs(csv, []apiextensionsv1.CustomResourceDefinition{crd})
+			Expect(qualities).To(HaveLen(2))
+			Expect(qualities[0].Found).To(BeTrue())
+			Expect(qualities[1].Found).To(BeFalse())
+			Expect(qualities[1].Findings).To(Equal([]string{"no matching CustomResourceDefinition"}))
+		})
+	})
+})
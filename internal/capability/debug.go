@@ -37,13 +37,7 @@ func CollectDebugData(ctx context.Context, options auditOptions, reportName stri
 
 		if len(EventList.Items) > 0 {
 			for _, event := range EventList.Items {
-				events = append(events, report.Event{
-					InvolvedObjName:   event.InvolvedObject.Name,
-					InvolvedObjkind:   event.InvolvedObject.Kind,
-					CreationTimestamp: event.CreationTimestamp,
-					Message:           strings.Replace(event.Message, "\"", "", -1),
-					Reason:            event.Reason,
-				})
+				events = append(events, reportEvent(event))
 			}
 		}
 	}
@@ -64,13 +58,7 @@ func CollectDebugData(ctx context.Context, options auditOptions, reportName stri
 			}
 			if len(EventList.Items) > 0 {
 				for _, event := range EventList.Items {
-					podEvents = append(podEvents, report.Event{
-						InvolvedObjName:   event.InvolvedObject.Name,
-						InvolvedObjkind:   event.InvolvedObject.Kind,
-						CreationTimestamp: event.CreationTimestamp,
-						Message:           strings.Replace(event.Message, "\"", "", -1),
-						Reason:            event.Reason,
-					})
+					podEvents = append(podEvents, reportEvent(event))
 				}
 			}
 			for _, container := range pod.Spec.Containers {
@@ -151,14 +139,27 @@ func OperatorPods(ctx context.Context, clientset *kubernetes.Clientset, namespac
 	return podList, nil
 }
 
-func EventsByNameAndKind(ctx context.Context, clientset *kubernetes.Clientset, name string, kind string, namespace string) (*corev1.EventList, error) {
-	events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "involvedObject.name=" + name, TypeMeta: metav1.TypeMeta{Kind: kind}})
+func EventsByNameAndKind(ctx context.Context, clientset kubernetes.Interface, name string, kind string, namespace string) (*corev1.EventList, error) {
+	events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "involvedObject.name=" + name + ",involvedObject.kind=" + kind})
 	if err != nil {
 		return nil, fmt.Errorf("couldn't retrieve events: %s", err)
 	}
 	return events, nil
 }
 
+// reportEvent converts an event for the reports, dropping the quotes that would break the JSON templates
+func reportEvent(event corev1.Event) report.Event {
+	return report.Event{
+		InvolvedObjName:   event.InvolvedObject.Name,
+		InvolvedObjkind:   event.InvolvedObject.Kind,
+		CreationTimestamp: event.CreationTimestamp,
+		Message:           strings.Replace(event.Message, "\"", "", -1),
+		Reason:            event.Reason,
+		Type:              event.Type,
+		Source:            eventSource(event),
+	}
+}
+
 func Logs(ctx context.Context, clientset *kubernetes.Clientset, pod corev1.Pod, container string) (string, error) {
 	podLogOpts := corev1.PodLogOptions{Container: container}
 	req := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &podLogOpts)
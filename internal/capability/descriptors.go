@@ -0,0 +1,43 @@
+package capability
+
+import (
+	"strings"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	podCountDescriptor      = "urn:alm:descriptor:com.tectonic.ui:podCount"
+	booleanSwitchDescriptor = "urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+)
+
+// specDescriptorFields returns the fields of operand tagged with xDescriptor by the spec descriptors of
+// the matching CSV owned CRD. Each entry holds the fields from the object root, e.g. ["spec", "replicas"].
+// Descriptor paths indexing into arrays are skipped.
+func specDescriptorFields(csv *operatorv1alpha1.ClusterServiceVersion, operand unstructured.Unstructured, xDescriptor string) [][]string {
+	if csv == nil {
+		return nil
+	}
+
+	gvk := operand.GroupVersionKind()
+	fields := [][]string{}
+	for _, owned := range csv.Spec.CustomResourceDefinitions.Owned {
+		if owned.Kind != gvk.Kind || !strings.HasSuffix(owned.Name, "."+gvk.Group) {
+			continue
+		}
+		for _, descriptor := range owned.SpecDescriptors {
+			if descriptor.Path == "" || strings.ContainsAny(descriptor.Path, "[]") {
+				continue
+			}
+			for _, x := range descriptor.XDescriptors {
+				if x == xDescriptor {
+					fields = append(fields, append([]string{"spec"}, strings.Split(descriptor.Path, ".")...))
+					break
+				}
+			}
+		}
+	}
+
+	return fields
+}
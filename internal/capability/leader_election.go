@@ -0,0 +1,278 @@
+package capability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// leaderElectionReplicas is the number of replicas the operator deployments are scaled to
+	leaderElectionReplicas = int32(2)
+	// leaderAnnotation holds the election record of ConfigMap locks used by client-go leader election
+	leaderAnnotation = "control-plane.alpha.kubernetes.io/leader"
+)
+
+// leaderLock is a Lease or ConfigMap used as a leader election lock, along with the identity of its holder
+type leaderLock struct {
+	kind   string
+	name   string
+	holder string
+}
+
+// leaderLocks lists the leader election locks in namespace. Besides Leases and the ConfigMaps annotated by client-go,
+// the ConfigMaps owned by a Pod are used by the leader-for-life election of the operator-sdk.
+func leaderLocks(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]leaderLock, error) {
+	locks := []leaderLock{}
+
+	leases, err := clientset.CoordinationV1().Leases(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list leases: %v", err)
+	}
+	for _, lease := range leases.Items {
+		lock := leaderLock{kind: "Lease", name: lease.Name}
+		if lease.Spec.HolderIdentity != nil {
+			lock.holder = *lease.Spec.HolderIdentity
+		}
+		locks = append(locks, lock)
+	}
+
+	configMaps, err := clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list configmaps: %v", err)
+	}
+	for _, cm := range configMaps.Items {
+		if annotation, ok := cm.Annotations[leaderAnnotation]; ok {
+			var record struct {
+				HolderIdentity string `json:"holderIdentity"`
+			}
+			if err := json.Unmarshal([]byte(annotation), &record); err != nil {
+				return nil, fmt.Errorf("could not read leader election record of configmap %s: %v", cm.Name, err)
+			}
+			locks = append(locks, leaderLock{kind: "ConfigMap", name: cm.Name, holder: record.HolderIdentity})
+			continue
+		}
+		for _, ref := range cm.OwnerReferences {
+			if ref.Kind == "Pod" {
+				locks = append(locks, leaderLock{kind: "ConfigMap", name: cm.Name, holder: ref.Name})
+			}
+		}
+	}
+
+	return locks, nil
+}
+
+// holderPod returns the pod holding a lock. The identity used by client-go is the pod name, optionally followed
+// by an underscore and a unique suffix.
+func holderPod(holder string, pods []corev1.Pod) (string, bool) {
+	for _, pod := range pods {
+		if holder == pod.Name || strings.HasPrefix(holder, pod.Name+"_") {
+			return pod.Name, true
+		}
+	}
+	return "", false
+}
+
+// heldLocks returns the locks held by one of pods and the names of the holding pods
+func heldLocks(locks []leaderLock, pods []corev1.Pod) ([]leaderLock, []string) {
+	held := []leaderLock{}
+	holders := map[string]bool{}
+	for _, lock := range locks {
+		if pod, ok := holderPod(lock.holder, pods); ok {
+			held = append(held, lock)
+			holders[pod] = true
+		}
+	}
+
+	names := []string{}
+	for pod := range holders {
+		names = append(names, pod)
+	}
+	sort.Strings(names)
+
+	return held, names
+}
+
+// scaleDeployment sets the number of replicas of a deployment
+func scaleDeployment(ctx context.Context, clientset kubernetes.Interface, namespace, name string, replicas int32) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		deployment.Spec.Replicas = &replicas
+		_, err = clientset.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// waitForFailover deletes the leader pod and waits for another pod of the deployment to take the lock over. Every
+// new holder identity seen on the lock is appended to the holder changes.
+func waitForFailover(ctx context.Context, options *auditOptions, clientset kubernetes.Interface, election *report.LeaderElection, lock leaderLock) error {
+	leader := election.LeaderPods[0]
+	if err := clientset.CoreV1().Pods(options.namespace).Delete(ctx, leader, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("could not delete leader pod %s: %v", leader, err)
+	}
+	start := time.Now()
+
+	err := wait.PollImmediateWithContext(ctx, time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		locks, err := leaderLocks(ctx, clientset, options.namespace)
+		if err != nil {
+			return false, err
+		}
+		pods, err := deploymentPods(ctx, clientset, options.namespace, []string{election.Deployment})
+		if err != nil {
+			return false, err
+		}
+
+		for _, l := range locks {
+			if l.kind != lock.kind || l.name != lock.name || l.holder == "" {
+				continue
+			}
+			if l.holder != election.HolderChanges[len(election.HolderChanges)-1] {
+				election.HolderChanges = append(election.HolderChanges, l.holder)
+			}
+			pod, ok := holderPod(l.holder, pods)
+			return ok && pod != leader, nil
+		}
+		return false, nil
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return err
+	}
+	election.FailedOver = err == nil
+	if election.FailedOver {
+		election.FailoverTime = time.Since(start).Round(time.Second)
+	}
+
+	return nil
+}
+
+// leaderElectionPassed tells if a single replica held the lock and another one took it over after the leader left
+func leaderElectionPassed(election report.LeaderElection) bool {
+	return election.Error == "" && len(election.LeaderPods) == 1 && election.FailedOver
+}
+
+// checkLeaderElection scales an operator deployment to leaderElectionReplicas, looks for the lock held by its
+// pods and deletes the leader pod to check failover. The deployment is scaled back afterwards.
+func checkLeaderElection(ctx context.Context, options *auditOptions, clientset kubernetes.Interface, name string) report.LeaderElection {
+	election := report.LeaderElection{Deployment: name}
+
+	deployment, err := clientset.AppsV1().Deployments(options.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		election.Error = fmt.Sprintf("could not get deployment: %v", err)
+		return election
+	}
+	election.Replicas = 1
+	if deployment.Spec.Replicas != nil {
+		election.Replicas = *deployment.Spec.Replicas
+	}
+
+	if election.Replicas < leaderElectionReplicas {
+		if err := scaleDeployment(ctx, clientset, options.namespace, name, leaderElectionReplicas); err != nil {
+			election.Error = fmt.Sprintf("could not scale deployment: %v", err)
+			return election
+		}
+		defer func() {
+			if err := scaleDeployment(ctx, clientset, options.namespace, name, election.Replicas); err != nil {
+				logger.Errorw("could not scale operator deployment back", "error", err, "deployment", name, "replicas", election.Replicas)
+			}
+		}()
+	}
+
+	err = wait.PollImmediateWithContext(ctx, 5*time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		return deploymentPodsReady(ctx, clientset, options.namespace, []string{name}, nil)
+	})
+	if err != nil {
+		election.Error = fmt.Sprintf("deployment did not reach %d ready replicas: %v", leaderElectionReplicas, err)
+		return election
+	}
+
+	var held []leaderLock
+	err = wait.PollImmediateWithContext(ctx, time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		locks, err := leaderLocks(ctx, clientset, options.namespace)
+		if err != nil {
+			return false, err
+		}
+		pods, err := deploymentPods(ctx, clientset, options.namespace, []string{name})
+		if err != nil {
+			return false, err
+		}
+		held, election.LeaderPods = heldLocks(locks, pods)
+		return len(held) > 0, nil
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		election.Error = "no leader election lock is held by the operator pods"
+		return election
+	}
+	if err != nil {
+		election.Error = err.Error()
+		return election
+	}
+
+	lock := held[0]
+	election.Lock = strings.Join([]string{lock.kind, lock.name}, "/")
+	election.HolderChanges = []string{lock.holder}
+	if len(election.LeaderPods) != 1 {
+		logger.Infow("skipping failover since several replicas hold leader election locks", "deployment", name, "pods", election.LeaderPods)
+		return election
+	}
+
+	if err := waitForFailover(ctx, options, clientset, &election, lock); err != nil {
+		election.Error = err.Error()
+	}
+
+	return election
+}
+
+// leaderElection scales each operator deployment to several replicas and checks that a single replica leads and
+// that another one takes over when the leader goes away
+func leaderElection(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking leader election for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+		if len(csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs) == 0 {
+			logger.Infow("exiting LeaderElection since the CSV has no deployments")
+			return nil
+		}
+
+		clientset, err := k8sClientset()
+		if err != nil {
+			return err
+		}
+
+		elections := []report.LeaderElection{}
+		for _, deployment := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+			election := checkLeaderElection(ctx, &options, clientset, deployment.Name)
+			election.Passed = leaderElectionPassed(election)
+			elections = append(elections, election)
+		}
+
+		return writeReports(&options, "leader_election", report.TemplateData{
+			LeaderElection: elections,
+		}, report.LeaderElectionJsonReport, report.LeaderElectionTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,120 @@
+package capability
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/report"
+
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Leader election", func() {
+	When("listing leader election locks", func() {
+		It("should read the holder of leases and configmap locks", func() {
+			holder := "operator-abcde_1234"
+			clientset := fake.NewSimpleClientset(
+				&coordinationv1.Lease{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "lease.example.com"},
+					Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder},
+				},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "testns",
+					Name:        "annotated.example.com",
+					Annotations: map[string]string{leaderAnnotation: `{"holderIdentity":"operator-fghij_5678","leaseDurationSeconds":15}`},
+				}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "testns",
+					Name:            "leader-for-life",
+					OwnerReferences: []metav1.OwnerReference{{Kind: "Pod", Name: "operator-klmno"}},
+				}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "config"}},
+			)
+
+			locks, err := leaderLocks(context.TODO(), clientset, "testns")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(locks).To(ConsistOf(
+				leaderLock{kind: "Lease", name: "lease.example.com", holder: "operator-abcde_1234"},
+				leaderLock{kind: "ConfigMap", name: "annotated.example.com", holder: "operator-fghij_5678"},
+				leaderLock{kind: "ConfigMap", name: "leader-for-life", holder: "operator-klmno"},
+			))
+		})
+	})
+	When("matching lock holders with the operator pods", func() {
+		It("should only count the locks held by the pods", func() {
+			pods := []corev1.Pod{
+				*operatorPod("operator-abcde", "1", true),
+				*operatorPod("operator-abcde2", "2", true),
+			}
+			locks := []leaderLock{
+				{kind: "Lease", name: "lease.example.com", holder: "operator-abcde_1234"},
+				{kind: "ConfigMap", name: "lease.example.com", holder: "operator-abcde_1234"},
+				{kind: "Lease", name: "other.example.com", holder: "other-operator_5678"},
+				{kind: "Lease", name: "released.example.com"},
+			}
+
+			held, holders := heldLocks(locks, pods)
+			Expect(held).To(HaveLen(2))
+			Expect(holders).To(Equal([]string{"operator-abcde"}))
+		})
+	})
+	When("checking failover", func() {
+		var clientset *fake.Clientset
+		var election report.LeaderElection
+		var lock leaderLock
+
+		BeforeEach(func() {
+			holder := "operator-fghij_5678"
+			clientset = fake.NewSimpleClientset(
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "operator"},
+					Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "operator"}}},
+				},
+				operatorPod("operator-abcde", "1", true),
+				operatorPod("operator-fghij", "2", true),
+				&coordinationv1.Lease{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "lease.example.com"},
+					Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder},
+				},
+			)
+			election = report.LeaderElection{
+				Deployment:    "operator",
+				LeaderPods:    []string{"operator-abcde"},
+				HolderChanges: []string{"operator-abcde_1234"},
+			}
+			lock = leaderLock{kind: "Lease", name: "lease.example.com", holder: "operator-abcde_1234"}
+		})
+		It("should delete the leader and report the new holder", func() {
+			options := &auditOptions{namespace: "testns", csvWaitTime: time.Second}
+			Expect(waitForFailover(context.TODO(), options, clientset, &election, lock)).To(Succeed())
+			Expect(election.FailedOver).To(BeTrue())
+			Expect(election.HolderChanges).To(Equal([]string{"operator-abcde_1234", "operator-fghij_5678"}))
+
+			_, err := clientset.CoreV1().Pods("testns").Get(context.TODO(), "operator-abcde", metav1.GetOptions{})
+			Expect(err).To(HaveOccurred())
+		})
+		It("should not fail over while the lock is held by the deleted leader", func() {
+			lock.name = "missing.example.com"
+			options := &auditOptions{namespace: "testns", csvWaitTime: time.Millisecond}
+			Expect(waitForFailover(context.TODO(), options, clientset, &election, lock)).To(Succeed())
+			Expect(election.FailedOver).To(BeFalse())
+			Expect(leaderElectionPassed(election)).To(BeFalse())
+		})
+	})
+	When("scaling the operator deployment", func() {
+		It("should update the replicas", func() {
+			clientset := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "operator"}})
+			Expect(scaleDeployment(context.TODO(), clientset, "testns", "operator", leaderElectionReplicas)).To(Succeed())
+
+			deployment, err := clientset.AppsV1().Deployments("testns").Get(context.TODO(), "operator", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*deployment.Spec.Replicas).To(Equal(int32(2)))
+		})
+	})
+})
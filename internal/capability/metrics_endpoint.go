@@ -0,0 +1,198 @@
+package capability
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/prometheus/common/expfmt"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// metricsPath is where the Prometheus exposition format is served by convention
+const metricsPath = "/metrics"
+
+// isMetricsPort tells if a service port serves metrics, following the naming used by operator-sdk and
+// kubebuilder scaffolding for metrics services and ports
+func isMetricsPort(service corev1.Service, port corev1.ServicePort) bool {
+	return strings.Contains(port.Name, "metrics") || strings.Contains(service.Name, "metrics")
+}
+
+// portScheme guesses the scheme of a service port. kube-rbac-proxy serves metrics over TLS on 8443.
+func portScheme(port corev1.ServicePort) string {
+	if strings.Contains(port.Name, "https") || port.Port == 443 || port.Port == 8443 {
+		return "https"
+	}
+	return "http"
+}
+
+// parseMetrics parses a Prometheus text exposition and returns the sorted names of the metric families found
+func parseMetrics(body []byte) ([]string, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// canProxyServices tells if the audit user may scrape the services of namespace through the API server service
+// proxy. Without that permission every scrape is refused by the API server rather than by the endpoint.
+func canProxyServices(ctx context.Context, clientset kubernetes.Interface, namespace string) (bool, error) {
+	review, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "get",
+				Resource:    "services",
+				Subresource: "proxy",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("could not review access to the service proxy: %v", err)
+	}
+	return review.Status.Allowed, nil
+}
+
+// ownedServices returns the services in objs owned, directly or through other objects in objs, by one of owners,
+// along with the services OLM created for csv
+func ownedServices(objs []unstructured.Unstructured, csv *operatorv1alpha1.ClusterServiceVersion, owners []types.UID) []unstructured.Unstructured {
+	owned := map[types.UID]bool{}
+	for _, owner := range owners {
+		for _, obj := range ownedBy(objs, owner) {
+			owned[obj.GetUID()] = true
+		}
+	}
+
+	services := []unstructured.Unstructured{}
+	for _, obj := range objs {
+		if obj.GetKind() != "Service" {
+			continue
+		}
+		labels := obj.GetLabels()
+		if owned[obj.GetUID()] || (labels[olmOwnerLabel] == csv.Name && labels[olmOwnerNamespaceLabel] == csv.Namespace) {
+			services = append(services, obj)
+		}
+	}
+	return services
+}
+
+// scrapeMetricsEndpoint scrapes a service port through the API server service proxy. Endpoints the audit user
+// may not proxy to are reported as forbidden without being scraped, so that a 401 or 403 from the scrape comes
+// from the endpoint itself.
+func scrapeMetricsEndpoint(ctx context.Context, clientset kubernetes.Interface, service corev1.Service, port corev1.ServicePort, proxyAllowed bool) report.MetricsEndpoint {
+	endpoint := report.MetricsEndpoint{
+		Service: service.Namespace + "/" + service.Name,
+		Port:    port.Name,
+		Scheme:  portScheme(port),
+	}
+	if endpoint.Port == "" {
+		endpoint.Port = strconv.Itoa(int(port.Port))
+	}
+	if !proxyAllowed {
+		endpoint.ProxyForbidden = true
+		return endpoint
+	}
+
+	body, err := clientset.CoreV1().Services(service.Namespace).ProxyGet(endpoint.Scheme, service.Name, endpoint.Port, metricsPath, nil).DoRaw(ctx)
+	switch {
+	case apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err):
+		// the service proxy doesn't forward credentials, so an endpoint behind kube-rbac-proxy refuses the request
+		endpoint.Reachable = true
+		endpoint.RequiresAuth = true
+		return endpoint
+	case err != nil:
+		endpoint.Error = err.Error()
+		return endpoint
+	}
+	endpoint.Reachable = true
+
+	endpoint.Families, err = parseMetrics(body)
+	if err != nil {
+		endpoint.Error = fmt.Sprintf("could not parse metrics: %v", err)
+	}
+
+	return endpoint
+}
+
+// metricsEndpoint scrapes the metrics ports of the services owned by the operator or its operands in the
+// operator's own and target namespaces and reports the metric families they expose
+func metricsEndpoint(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("scraping metrics endpoints for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+		owners := []types.UID{csv.UID}
+		for _, operand := range *options.operands {
+			owners = append(owners, operand.GetUID())
+		}
+
+		clientset, err := k8sClientset()
+		if err != nil {
+			return fmt.Errorf("could not get clientset: %v", err)
+		}
+
+		endpoints := []report.MetricsEndpoint{}
+		for _, ns := range auditNamespaces(&options) {
+			proxyAllowed, err := canProxyServices(ctx, clientset, ns)
+			if err != nil {
+				return err
+			}
+
+			objs, err := listResources(ctx, &options, ns, ownedKinds)
+			if err != nil {
+				return fmt.Errorf("could not list resources in namespace %s: %v", ns, err)
+			}
+
+			for _, obj := range ownedServices(objs, csv, owners) {
+				var service corev1.Service
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &service); err != nil {
+					return fmt.Errorf("could not read service %s: %v", obj.GetName(), err)
+				}
+				for _, port := range service.Spec.Ports {
+					if !isMetricsPort(service, port) {
+						continue
+					}
+					endpoint := scrapeMetricsEndpoint(ctx, clientset, service, port, proxyAllowed)
+					if endpoint.Error != "" {
+						logger.Debugw("could not scrape metrics endpoint", "service", endpoint.Service, "port", endpoint.Port, "error", endpoint.Error)
+					}
+					endpoints = append(endpoints, endpoint)
+				}
+			}
+		}
+
+		return writeReports(&options, "metrics_endpoint", report.TemplateData{
+			MetricsEndpoint: endpoints,
+		}, report.MetricsEndpointJsonReport, report.MetricsEndpointTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,98 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("Metrics endpoint", func() {
+	When("looking for metrics ports", func() {
+		It("should match metrics port names and metrics services", func() {
+			service := corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-webhook"}}
+			Expect(isMetricsPort(service, corev1.ServicePort{Name: "http-metrics", Port: 8080})).To(BeTrue())
+			Expect(isMetricsPort(service, corev1.ServicePort{Name: "https", Port: 443})).To(BeFalse())
+
+			service.Name = "test-controller-manager-metrics-service"
+			Expect(isMetricsPort(service, corev1.ServicePort{Name: "https", Port: 8443})).To(BeTrue())
+		})
+		It("should use https for kube-rbac-proxy ports", func() {
+			Expect(portScheme(corev1.ServicePort{Name: "https", Port: 8443})).To(Equal("https"))
+			Expect(portScheme(corev1.ServicePort{Name: "metrics", Port: 8443})).To(Equal("https"))
+			Expect(portScheme(corev1.ServicePort{Name: "metrics", Port: 8080})).To(Equal("http"))
+		})
+	})
+	When("selecting the services to scrape", func() {
+		It("should only keep the services owned by the operator or its operands", func() {
+			csv := &operatorv1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "testoperator.v1.0.0", Namespace: "testns", UID: "csv-uid"}}
+			labeled := newOwnedObject("Service", "webhook-service", "labeled-uid", "")
+			labeled.SetLabels(map[string]string{olmOwnerLabel: "testoperator.v1.0.0", olmOwnerNamespaceLabel: "testns"})
+			objs := []unstructured.Unstructured{
+				newOwnedObject("Deployment", "controller-manager", "deployment-uid", "csv-uid"),
+				newOwnedObject("Service", "controller-manager-metrics", "operator-service-uid", "deployment-uid"),
+				newOwnedObject("Service", "operand-metrics", "operand-service-uid", "operand-uid"),
+				newOwnedObject("Service", "unrelated-metrics", "unrelated-uid", "other-uid"),
+				newOwnedObject("Service", "standalone-metrics", "standalone-uid", ""),
+				labeled,
+			}
+
+			names := []string{}
+			for _, service := range ownedServices(objs, csv, []types.UID{"csv-uid", "operand-uid"}) {
+				names = append(names, service.GetName())
+			}
+			Expect(names).To(ConsistOf("controller-manager-metrics", "operand-metrics", "webhook-service"))
+		})
+	})
+	When("the audit user may not use the service proxy", func() {
+		var clientset *fake.Clientset
+
+		BeforeEach(func() {
+			clientset = fake.NewSimpleClientset()
+			clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				attributes := review.Spec.ResourceAttributes
+				review.Status.Allowed = attributes.Namespace == "allowed" && attributes.Resource == "services" && attributes.Subresource == "proxy"
+				return true, review, nil
+			})
+		})
+		It("should review access to the service proxy per namespace", func() {
+			Expect(canProxyServices(context.TODO(), clientset, "allowed")).To(BeTrue())
+			Expect(canProxyServices(context.TODO(), clientset, "testns")).To(BeFalse())
+		})
+		It("should report the endpoint as forbidden rather than requiring authentication", func() {
+			service := corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "metrics"}}
+			endpoint := scrapeMetricsEndpoint(context.TODO(), clientset, service, corev1.ServicePort{Name: "https", Port: 8443}, false)
+			Expect(endpoint.ProxyForbidden).To(BeTrue())
+			Expect(endpoint.RequiresAuth).To(BeFalse())
+			Expect(endpoint.Reachable).To(BeFalse())
+			Expect(endpoint.Scheme).To(Equal("https"))
+		})
+	})
+	When("parsing metrics", func() {
+		It("should list the metric families", func() {
+			families, err := parseMetrics([]byte(`# HELP workqueue_depth Current depth of workqueue
+# TYPE workqueue_depth gauge
+workqueue_depth{name="test"} 0
+# TYPE controller_runtime_reconcile_total counter
+controller_runtime_reconcile_total{controller="test",result="success"} 3
+`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(families).To(Equal([]string{"controller_runtime_reconcile_total", "workqueue_depth"}))
+		})
+		It("should fail on a body that is not in the exposition format", func() {
+			_, err := parseMetrics([]byte("<html><body>not found</body></html>\n"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,170 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// monitoringGroupVersion is the API group of the Prometheus operator kinds
+var monitoringGroupVersion = schema.GroupVersion{Group: "monitoring.coreos.com", Version: "v1"}
+
+// listMonitoringObjects lists the objects of a Prometheus operator kind in a namespace. A cluster without the
+// Prometheus operator CRDs simply has none of them.
+func listMonitoringObjects(ctx context.Context, options *auditOptions, ns, kind string) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(monitoringGroupVersion.WithKind(kind + "List"))
+	if err := options.client.ListUnstructured(ctx, ns, list); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not list %s in namespace %s: %v", kind, ns, err)
+	}
+	return list.Items, nil
+}
+
+// ownedMonitoringObjects returns the monitoring objects owned, directly or through objs, by one of owners, along
+// with the ones OLM created for csv from the bundle
+func ownedMonitoringObjects(monitors, objs []unstructured.Unstructured, csv *operatorv1alpha1.ClusterServiceVersion, owners []types.UID) []unstructured.Unstructured {
+	all := append(append([]unstructured.Unstructured{}, objs...), monitors...)
+	owned := map[types.UID]bool{}
+	for _, owner := range owners {
+		for _, obj := range ownedBy(all, owner) {
+			owned[obj.GetUID()] = true
+		}
+	}
+
+	filtered := []unstructured.Unstructured{}
+	for _, obj := range monitors {
+		labels := obj.GetLabels()
+		if owned[obj.GetUID()] || (labels[olmOwnerLabel] == csv.Name && labels[olmOwnerNamespaceLabel] == csv.Namespace) {
+			filtered = append(filtered, obj)
+		}
+	}
+	return filtered
+}
+
+// inspectPrometheusRule reads the alerting rules of a PrometheusRule and checks that each of them carries a
+// severity label and a runbook_url annotation. Recording rules are ignored.
+func inspectPrometheusRule(rule unstructured.Unstructured) []report.AlertRule {
+	alerts := []report.AlertRule{}
+
+	groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	for _, g := range groups {
+		group, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		groupName, _, _ := unstructured.NestedString(group, "name")
+		rules, _, _ := unstructured.NestedSlice(group, "rules")
+		for _, r := range rules {
+			rule, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, found, _ := unstructured.NestedString(rule, "alert")
+			if !found {
+				continue
+			}
+
+			alert := report.AlertRule{Group: groupName, Alert: name}
+			alert.Severity, _, _ = unstructured.NestedString(rule, "labels", "severity")
+			alert.RunbookURL, _, _ = unstructured.NestedString(rule, "annotations", "runbook_url")
+			if alert.Severity == "" {
+				alert.Findings = append(alert.Findings, "no severity label")
+			}
+			if alert.RunbookURL == "" {
+				alert.Findings = append(alert.Findings, "no runbook_url annotation")
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts
+}
+
+// monitoringPassed tells if the operator exposes metrics through a ServiceMonitor or PodMonitor and ships alerting
+// rules that all carry a severity label and a runbook_url annotation
+func monitoringPassed(monitoring report.Monitoring) bool {
+	if len(monitoring.ServiceMonitors)+len(monitoring.PodMonitors) == 0 || len(monitoring.Alerts) == 0 {
+		return false
+	}
+	for _, alert := range monitoring.Alerts {
+		if len(alert.Findings) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// monitoring discovers the ServiceMonitors, PodMonitors and PrometheusRules created by the operator or its operands
+// in the operator's own and target namespaces and checks the alerting rules for the Level 4 criteria
+func monitoring(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking monitoring for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+		owners := []types.UID{csv.UID}
+		for _, operand := range *options.operands {
+			owners = append(owners, operand.GetUID())
+		}
+
+		monitoring := report.Monitoring{}
+		for _, ns := range auditNamespaces(&options) {
+			objs, err := listResources(ctx, &options, ns, ownedKinds)
+			if err != nil {
+				return fmt.Errorf("could not list resources in namespace %s: %v", ns, err)
+			}
+
+			serviceMonitors, err := listMonitoringObjects(ctx, &options, ns, "ServiceMonitor")
+			if err != nil {
+				return err
+			}
+			for _, obj := range ownedMonitoringObjects(serviceMonitors, objs, csv, owners) {
+				monitoring.ServiceMonitors = append(monitoring.ServiceMonitors, obj.GetNamespace()+"/"+obj.GetName())
+			}
+
+			podMonitors, err := listMonitoringObjects(ctx, &options, ns, "PodMonitor")
+			if err != nil {
+				return err
+			}
+			for _, obj := range ownedMonitoringObjects(podMonitors, objs, csv, owners) {
+				monitoring.PodMonitors = append(monitoring.PodMonitors, obj.GetNamespace()+"/"+obj.GetName())
+			}
+
+			rules, err := listMonitoringObjects(ctx, &options, ns, "PrometheusRule")
+			if err != nil {
+				return err
+			}
+			for _, obj := range ownedMonitoringObjects(rules, objs, csv, owners) {
+				monitoring.PrometheusRules = append(monitoring.PrometheusRules, obj.GetNamespace()+"/"+obj.GetName())
+				for _, alert := range inspectPrometheusRule(obj) {
+					alert.PrometheusRule = obj.GetNamespace() + "/" + obj.GetName()
+					monitoring.Alerts = append(monitoring.Alerts, alert)
+				}
+			}
+		}
+
+		monitoring.Passed = monitoringPassed(monitoring)
+
+		return writeReports(&options, "monitoring", report.TemplateData{
+			Monitoring: monitoring,
+		}, report.MonitoringJsonReport, report.MonitoringTextReport)
+	}, noCleanup
+}
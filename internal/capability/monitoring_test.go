@@ -0,0 +1,112 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Monitoring", func() {
+	When("selecting the monitoring objects", func() {
+		It("should only keep the objects owned by the operator or its operands", func() {
+			csv := &operatorv1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "testoperator.v1.0.0", Namespace: "testns", UID: "csv-uid"}}
+			labeled := newOwnedObject("PrometheusRule", "bundled-rules", "labeled-uid", "")
+			labeled.SetLabels(map[string]string{olmOwnerLabel: "testoperator.v1.0.0", olmOwnerNamespaceLabel: "testns"})
+			objs := []unstructured.Unstructured{
+				newOwnedObject("Deployment", "controller-manager", "deployment-uid", "csv-uid"),
+			}
+			monitors := []unstructured.Unstructured{
+				newOwnedObject("ServiceMonitor", "controller-manager-metrics", "operator-monitor-uid", "deployment-uid"),
+				newOwnedObject("ServiceMonitor", "operand-metrics", "operand-monitor-uid", "operand-uid"),
+				newOwnedObject("ServiceMonitor", "unrelated-metrics", "unrelated-uid", "other-uid"),
+				newOwnedObject("ServiceMonitor", "standalone-metrics", "standalone-uid", ""),
+				labeled,
+			}
+
+			names := []string{}
+			for _, obj := range ownedMonitoringObjects(monitors, objs, csv, []types.UID{"csv-uid", "operand-uid"}) {
+				names = append(names, obj.GetName())
+			}
+			Expect(names).To(ConsistOf("controller-manager-metrics", "operand-metrics", "bundled-rules"))
+		})
+	})
+	When("inspecting a PrometheusRule", func() {
+		It("should check the alerting rules and ignore recording rules", func() {
+			rule := unstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"groups": []interface{}{
+						map[string]interface{}{
+							"name": "test.rules",
+							"rules": []interface{}{
+								map[string]interface{}{
+									"record": "job:up:sum",
+									"expr":   "sum(up) by (job)",
+								},
+								map[string]interface{}{
+									"alert":       "Complete",
+									"expr":        "up == 0",
+									"labels":      map[string]interface{}{"severity": "critical"},
+									"annotations": map[string]interface{}{"runbook_url": "https://example.com/runbook"},
+								},
+								map[string]interface{}{
+									"alert": "Incomplete",
+									"expr":  "up == 0",
+								},
+							},
+						},
+					},
+				},
+			}}
+
+			alerts := inspectPrometheusRule(rule)
+			Expect(alerts).To(HaveLen(2))
+			Expect(alerts[0].Group).To(Equal("test.rules"))
+			Expect(alerts[0].Alert).To(Equal("Complete"))
+			Expect(alerts[0].Severity).To(Equal("critical"))
+			Expect(alerts[0].RunbookURL).To(Equal("https://example.com/runbook"))
+			Expect(alerts[0].Findings).To(BeEmpty())
+			Expect(alerts[1].Findings).To(ConsistOf("no severity label", "no runbook_url annotation"))
+		})
+	})
+	When("deciding the result", func() {
+		var monitoring report.Monitoring
+
+		BeforeEach(func() {
+			monitoring = report.Monitoring{
+				ServiceMonitors: []string{"test/metrics"},
+				Alerts:          []report.AlertRule{{Alert: "Complete", Severity: "critical", RunbookURL: "https://example.com/runbook"}},
+			}
+		})
+		It("should pass with metrics and complete alerts", func() {
+			Expect(monitoringPassed(monitoring)).To(BeTrue())
+		})
+		It("should fail without a ServiceMonitor or PodMonitor", func() {
+			monitoring.ServiceMonitors = nil
+			Expect(monitoringPassed(monitoring)).To(BeFalse())
+		})
+		It("should fail without alerts", func() {
+			monitoring.Alerts = nil
+			Expect(monitoringPassed(monitoring)).To(BeFalse())
+		})
+		It("should fail when an alert has findings", func() {
+			monitoring.Alerts = append(monitoring.Alerts, report.AlertRule{Alert: "Incomplete", Findings: []string{"no severity label"}})
+			Expect(monitoringPassed(monitoring)).To(BeFalse())
+		})
+	})
+	When("the Prometheus operator CRDs are not installed", func() {
+		It("should not find any monitoring objects", func() {
+			options := &auditOptions{client: operator.NewFakeOpClient()}
+			objs, err := listMonitoringObjects(context.Background(), options, "test", "ServiceMonitor")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(objs).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// namespaceAdminRoles are the default ClusterRoles OLM aggregates the APIs owned by a CSV into
+var namespaceAdminRoles = []string{"admin", "edit"}
+
+// namespaceAdminUser is the user impersonated with one of the namespaceAdminRoles in the audit namespace
+func namespaceAdminUser(role string) string {
+	return "opcap-namespace-" + role
+}
+
+// checkKindAccess creates, reads and deletes a copy of cr as an impersonated user. When the user can't create it,
+// the copy is created by the audit's own client so that read and delete can still be checked.
+func checkKindAccess(ctx context.Context, options *auditOptions, user operator.Client, cr map[string]interface{}, suffix string) report.KindAccess {
+	obj := (&unstructured.Unstructured{Object: cr}).DeepCopy()
+	obj.SetNamespace(options.namespace)
+	obj.SetName(obj.GetName() + "-" + suffix)
+
+	access := report.KindAccess{Kind: obj.GetKind()}
+	fail := func(verb string, err error) {
+		access.Errors = append(access.Errors, fmt.Sprintf("%s: %v", verb, err))
+	}
+
+	if err := user.CreateUnstructured(ctx, obj.DeepCopy()); err != nil {
+		fail("create", err)
+		if err := options.client.CreateUnstructured(ctx, obj.DeepCopy()); err != nil {
+			logger.Errorw("could not create resource", "error", err, "kind", obj.GetKind(), "namespace", options.namespace)
+			return access
+		}
+	} else {
+		access.Create = true
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := user.GetUnstructured(ctx, obj.GetNamespace(), obj.GetName(), current); err != nil {
+		fail("get", err)
+	} else {
+		access.Read = true
+	}
+
+	if err := user.DeleteUnstructured(ctx, obj.DeepCopy()); err != nil {
+		fail("delete", err)
+		// don't leave the copy behind
+		if err := options.client.DeleteUnstructured(ctx, obj.DeepCopy()); err != nil && !apierrors.IsNotFound(err) {
+			logger.Errorw("could not delete resource", "error", err, "kind", obj.GetKind(), "namespace", options.namespace)
+		}
+	} else {
+		access.Delete = true
+	}
+
+	return access
+}
+
+// namespaceAdminPassed tells if a namespace admin can create, read and delete every kind
+func namespaceAdminPassed(kinds []report.KindAccess) bool {
+	for _, kind := range kinds {
+		if !kind.Create || !kind.Read || !kind.Delete {
+			return false
+		}
+	}
+	return len(kinds) > 0
+}
+
+// namespaceAdmin creates the ALM examples while impersonating users that only have the admin or edit roles in the
+// operator's namespace, checking that OLM aggregated the APIs owned by the CSV into those roles
+func namespaceAdmin(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking namespace admin usability for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if err := extractAlmExamples(ctx, &options); err != nil {
+			logger.Errorf("could not get ALM Examples: %v", err)
+		}
+		if len(options.customResources) == 0 {
+			logger.Infow("exiting NamespaceAdmin since no ALM_Examples found in CSV")
+			return nil
+		}
+
+		kubeconfig, err := kubeConfig()
+		if err != nil {
+			return err
+		}
+
+		admins := []report.NamespaceAdmin{}
+		for _, role := range namespaceAdminRoles {
+			user := namespaceAdminUser(role)
+			if err := options.client.BindClusterRole(ctx, user, options.namespace, role, user); err != nil {
+				return err
+			}
+			client, err := operator.NewImpersonatingOpCapClient(kubeconfig, user, []string{"system:authenticated"})
+			if err != nil {
+				return fmt.Errorf("could not impersonate %s: %v", user, err)
+			}
+
+			admin := report.NamespaceAdmin{Role: role, User: user}
+			checked := map[string]bool{}
+			for _, cr := range options.customResources {
+				obj := &unstructured.Unstructured{Object: cr}
+				if checked[obj.GroupVersionKind().String()] {
+					continue
+				}
+				checked[obj.GroupVersionKind().String()] = true
+				admin.Kinds = append(admin.Kinds, checkKindAccess(ctx, &options, client, cr, role))
+			}
+			admin.Passed = namespaceAdminPassed(admin.Kinds)
+			admins = append(admins, admin)
+		}
+
+		return writeReports(&options, "namespace_admin", report.TemplateData{
+			NamespaceAdmin: admins,
+		}, report.NamespaceAdminJsonReport, report.NamespaceAdminTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,68 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// createForbiddenClient is an operator.Client whose user isn't allowed to create objects
+type createForbiddenClient struct {
+	operator.Client
+}
+
+func (c createForbiddenClient) CreateUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
+	return apierrors.NewForbidden(corev1.Resource("configmaps"), obj.GetName(), nil)
+}
+
+var _ = Describe("Namespace admin", func() {
+	var client operator.Client
+	var options *auditOptions
+	var cr map[string]interface{}
+
+	BeforeEach(func() {
+		client = operator.NewFakeOpClient()
+		options = &auditOptions{client: client, namespace: "testns"}
+		cr = map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "operand"},
+		}
+	})
+
+	When("the user can manage the kind", func() {
+		It("should allow every verb and leave nothing behind", func() {
+			access := checkKindAccess(context.TODO(), options, client, cr, "admin")
+			Expect(access).To(Equal(report.KindAccess{Kind: "ConfigMap", Create: true, Read: true, Delete: true}))
+
+			obj := &unstructured.Unstructured{Object: cr}
+			Expect(apierrors.IsNotFound(client.GetUnstructured(context.TODO(), "testns", "operand-admin", obj))).To(BeTrue())
+			Expect(cr["metadata"]).To(Equal(map[string]interface{}{"name": "operand"}))
+		})
+	})
+	When("the user cannot create the kind", func() {
+		It("should still check read and delete", func() {
+			access := checkKindAccess(context.TODO(), options, createForbiddenClient{client}, cr, "edit")
+			Expect(access.Create).To(BeFalse())
+			Expect(access.Read).To(BeTrue())
+			Expect(access.Delete).To(BeTrue())
+			Expect(access.Errors).To(HaveLen(1))
+			Expect(access.Errors[0]).To(HavePrefix("create: "))
+		})
+	})
+	When("deciding the result", func() {
+		It("should pass only when every verb is allowed on every kind", func() {
+			kinds := []report.KindAccess{{Kind: "ConfigMap", Create: true, Read: true, Delete: true}}
+			Expect(namespaceAdminPassed(kinds)).To(BeTrue())
+			kinds = append(kinds, report.KindAccess{Kind: "Secret", Read: true, Delete: true})
+			Expect(namespaceAdminPassed(kinds)).To(BeFalse())
+			Expect(namespaceAdminPassed(nil)).To(BeFalse())
+		})
+	})
+})
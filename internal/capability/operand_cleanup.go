@@ -3,14 +3,20 @@ package capability
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/opdev/opcap/internal/logger"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
-// OperandCleanup removes the operand from the OCP cluster in the ca.namespace
+// operandDeletionGracePeriod is how long an operand is given to terminate on cleanup before its finalizers are
+// removed
+const operandDeletionGracePeriod = time.Minute
+
+// OperandCleanup removes the operands created by OperandInstall from the OCP cluster
 func operandCleanup(ctx context.Context, opts ...auditOption) auditCleanupFn {
 	var options auditOptions
 	for _, opt := range opts {
@@ -26,51 +32,66 @@ func operandCleanup(ctx context.Context, opts ...auditOption) auditCleanupFn {
 		logger.Debugw("cleaningUp operand for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode",
 			options.subscription.InstallModeType)
 
-		if len(options.customResources) > 0 {
-			for _, cr := range options.customResources {
-				obj := &unstructured.Unstructured{Object: cr}
-
-				// extract name from CustomResource object and delete it
-				name := obj.Object["metadata"].(map[string]interface{})["name"].(string)
-
-				// check if CR exists, only then cleanup the operand
-				err := options.client.GetUnstructured(ctx, options.namespace, name, obj)
-				if !apierrors.IsNotFound(err) {
-					// Actual error. Return it
-					return fmt.Errorf("could not get operaand: %v", err)
-				}
-				if obj == nil || apierrors.IsNotFound(err) {
-					// Did not find it. Somehow already gone.
-					// Not an error condition, but no point in
-					// continuing.
-					return nil
-				}
+		errs := []error{}
+		for _, operand := range *options.operands {
+			if err := removeOperand(ctx, &options, operand); err != nil {
+				logger.Debugf("failed operandCleanUp: package: %s error: %s\n", options.subscription.Package, err.Error())
+				errs = append(errs, err)
+			}
+		}
 
-				// delete the resource using the dynamic client
-				if err := options.client.DeleteUnstructured(ctx, obj); err != nil {
-					logger.Debugf("failed operandCleanUp: package: %s error: %s\n", options.subscription.Package, err.Error())
-					return err
-				}
+		// extra custom resources are also looked up in case they exist without having been installed as operands
+		for _, cr := range options.customResources {
+			obj := &unstructured.Unstructured{Object: cr}
+			if isOperand(*options.operands, *obj) {
+				continue
+			}
 
-				// Forcing cleanup of finalizers
-				err = options.client.GetUnstructured(ctx, options.namespace, name, obj)
-				if apierrors.IsNotFound(err) {
-					return nil
-				}
+			// check if CR exists, only then cleanup the operand
+			err := options.client.GetUnstructured(ctx, options.namespace, obj.GetName(), obj)
+			if apierrors.IsNotFound(err) {
+				// Did not find it. Somehow already gone.
+				continue
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not get operand: %v", err))
+				continue
+			}
 
-				obj.SetFinalizers([]string{})
+			if err := removeOperand(ctx, &options, *obj); err != nil {
+				logger.Debugf("failed operandCleanUp: package: %s error: %s\n", options.subscription.Package, err.Error())
+				errs = append(errs, err)
+			}
+		}
 
-				if err := options.client.UpdateUnstructured(ctx, obj); err != nil {
-					return err
-				}
+		return utilerrors.NewAggregate(errs)
+	}
+}
 
-				if err := options.client.GetUnstructured(ctx, options.namespace, name, obj); err != nil && !apierrors.IsNotFound(err) {
-					return fmt.Errorf("error cleaning up operand after deleting finalizer: %v", err)
-				}
-				return nil
-			}
+// isOperand tells if obj is one of the operands created by OperandInstall
+func isOperand(operands []unstructured.Unstructured, obj unstructured.Unstructured) bool {
+	for _, operand := range operands {
+		if operand.GroupVersionKind() == obj.GroupVersionKind() && operand.GetName() == obj.GetName() {
+			return true
 		}
+	}
+	return false
+}
 
+// removeOperand deletes an operand, giving the operator a chance to run its finalizers before they are removed
+func removeOperand(ctx context.Context, options *auditOptions, operand unstructured.Unstructured) error {
+	remaining, err := deleteOperandAndWait(ctx, options, operand, operandDeletionGracePeriod)
+	if err != nil {
+		return err
+	}
+	if remaining == nil {
 		return nil
 	}
+
+	// Forcing cleanup of finalizers as a last resort
+	logger.Infow("operand stuck terminating, removing finalizers", "kind", remaining.GetKind(), "name", remaining.GetName(), "finalizers", remaining.GetFinalizers())
+	if err := forceRemoveFinalizers(ctx, options, remaining); err != nil {
+		return fmt.Errorf("error cleaning up operand after deleting finalizer: %v", err)
+	}
+	return nil
 }
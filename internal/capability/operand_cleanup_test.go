@@ -0,0 +1,62 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Operand cleanup", func() {
+	var client operator.Client
+	var operands []unstructured.Unstructured
+	var opts []auditOption
+
+	BeforeEach(func() {
+		client = operator.NewFakeOpClient(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "installed", Namespace: "testns"}})
+		configMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "installed", Namespace: "testns"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		operands = []unstructured.Unstructured{{Object: configMap}}
+		opts = []auditOption{
+			withClient(client),
+			withNamespace("testns"),
+			withSubscription(&operator.SubscriptionData{Name: "testsub"}),
+			withOperands(&operands),
+			withCustomResources([]map[string]interface{}{{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "never-installed"},
+			}}),
+		}
+	})
+
+	It("should remove the operands created by OperandInstall and skip missing extra custom resources", func() {
+		Expect(operandCleanup(context.TODO(), opts...)(context.TODO())).To(Succeed())
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(operands[0].GroupVersionKind())
+		err := client.GetUnstructured(context.TODO(), "testns", "installed", obj)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should tell the operands apart from the extra custom resources", func() {
+		extra := unstructured.Unstructured{}
+		extra.SetAPIVersion("v1")
+		extra.SetKind("ConfigMap")
+		extra.SetName("never-installed")
+		Expect(isOperand(operands, extra)).To(BeFalse())
+
+		extra.SetName("installed")
+		Expect(isOperand(operands, extra)).To(BeTrue())
+	})
+})
@@ -0,0 +1,146 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// deleteOperandAndWait deletes an operand without touching its finalizers and waits up to timeout for it to be
+// gone. When the operand is still terminating after timeout it is returned as last seen, with the finalizers
+// holding it.
+func deleteOperandAndWait(ctx context.Context, options *auditOptions, operand unstructured.Unstructured, timeout time.Duration) (*unstructured.Unstructured, error) {
+	if err := options.client.DeleteUnstructured(ctx, operand.DeepCopy()); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not delete operand: %v", err)
+	}
+
+	var remaining *unstructured.Unstructured
+	err := wait.PollImmediateWithContext(ctx, time.Second, timeout, func(ctx context.Context) (bool, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(operand.GroupVersionKind())
+		err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), obj)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		remaining = obj
+		return false, nil
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return remaining, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// forceRemoveFinalizers strips the finalizers of an operand stuck terminating. It is a last resort that hides
+// operators hanging on deletion, so it is only used once a normal deletion timed out.
+func forceRemoveFinalizers(ctx context.Context, options *auditOptions, obj *unstructured.Unstructured) error {
+	obj.SetFinalizers(nil)
+	if err := options.client.UpdateUnstructured(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("could not remove finalizers: %v", err)
+	}
+	return nil
+}
+
+// waitForGarbageCollection waits for the objects owned by a deleted operand to be garbage collected through their
+// ownerReferences and returns the ones left after timeout
+func waitForGarbageCollection(ctx context.Context, options *auditOptions, operand unstructured.Unstructured, timeout time.Duration) ([]string, error) {
+	leftovers := []string{}
+	err := wait.PollImmediateWithContext(ctx, time.Second, timeout, func(ctx context.Context) (bool, error) {
+		owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, ownedKinds)
+		if err != nil {
+			return false, err
+		}
+		leftovers = []string{}
+		for _, obj := range owned {
+			leftovers = append(leftovers, obj.GetKind()+"/"+obj.GetName())
+		}
+		return len(leftovers) == 0, nil
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return nil, err
+	}
+	return leftovers, nil
+}
+
+// deleteOperand deletes an operand the way a user would, measures how long the deletion takes and checks that
+// its owned resources are garbage collected. Finalizers are only force removed when the operand never finishes
+// terminating.
+func deleteOperand(ctx context.Context, options *auditOptions, operand unstructured.Unstructured, timeout time.Duration) (report.OperandDeletion, error) {
+	deletion := report.OperandDeletion{
+		Kind: operand.GetKind(),
+		Name: operand.GetName(),
+	}
+
+	start := time.Now()
+	remaining, err := deleteOperandAndWait(ctx, options, operand, timeout)
+	if err != nil {
+		return deletion, err
+	}
+
+	if remaining != nil {
+		deletion.Finalizers = remaining.GetFinalizers()
+		logger.Infow("operand stuck terminating, removing finalizers", "kind", operand.GetKind(), "name", operand.GetName(), "finalizers", deletion.Finalizers)
+		if err := forceRemoveFinalizers(ctx, options, remaining); err != nil {
+			return deletion, err
+		}
+		deletion.ForcedFinalizerRemoval = true
+	} else {
+		deletion.Deleted = true
+		deletion.TimeToDelete = time.Since(start).Round(time.Second)
+	}
+
+	deletion.Leftovers, err = waitForGarbageCollection(ctx, options, operand, timeout)
+	if err != nil {
+		return deletion, err
+	}
+
+	return deletion, nil
+}
+
+// operandDeletion deletes each operand created by OperandInstall without removing its finalizers. Deleted operands
+// are dropped from the audit so that later audits in the plan skip them.
+func operandDeletion(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("deleting operands for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandDeletion") {
+			return nil
+		}
+
+		deletions := []report.OperandDeletion{}
+		for _, operand := range *options.operands {
+			deletion, err := deleteOperand(ctx, &options, operand, options.csvWaitTime)
+			if err != nil {
+				logger.Errorw("could not delete operand", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+			}
+			deletions = append(deletions, deletion)
+		}
+		*options.operands = []unstructured.Unstructured{}
+
+		return writeReports(&options, "operand_deletion", report.TemplateData{
+			OperandDeletion: deletions,
+		}, report.OperandDeletionJsonReport, report.OperandDeletionTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,58 @@
+package capability
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Operand deletion", func() {
+	var operand *corev1.ConfigMap
+	var options *auditOptions
+
+	newOperand := func() unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+		obj.SetNamespace(operand.Namespace)
+		obj.SetName(operand.Name)
+		return obj
+	}
+
+	BeforeEach(func() {
+		operand = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "operand", Namespace: "test"}}
+	})
+	When("the operand has no finalizers", func() {
+		It("should be deleted", func() {
+			options = &auditOptions{client: operator.NewFakeOpClient(operand)}
+
+			deletion, err := deleteOperand(context.Background(), options, newOperand(), 2*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletion.Deleted).To(BeTrue())
+			Expect(deletion.ForcedFinalizerRemoval).To(BeFalse())
+			Expect(deletion.Leftovers).To(BeEmpty())
+		})
+	})
+	When("the operand never finishes terminating", func() {
+		It("should force remove its finalizers", func() {
+			operand.Finalizers = []string{"test.opcap.io/finalizer"}
+			options = &auditOptions{client: operator.NewFakeOpClient(operand)}
+
+			deletion, err := deleteOperand(context.Background(), options, newOperand(), 2*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletion.Deleted).To(BeFalse())
+			Expect(deletion.Finalizers).To(ConsistOf("test.opcap.io/finalizer"))
+			Expect(deletion.ForcedFinalizerRemoval).To(BeTrue())
+
+			obj := newOperand()
+			err = options.client.GetUnstructured(context.Background(), "test", "operand", &obj)
+			Expect(apierrors.IsNotFound(err) || len(obj.GetFinalizers()) == 0).To(BeTrue())
+		})
+	})
+})
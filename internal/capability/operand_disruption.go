@@ -0,0 +1,232 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// availabilityMonitor accumulates the time an operand spends without its readiness condition
+type availabilityMonitor struct {
+	mu               sync.Mutex
+	unavailableSince time.Time
+	unavailable      time.Duration
+}
+
+// observe records the readiness of the operand at a point in time
+func (m *availabilityMonitor) observe(ready bool, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case !ready && m.unavailableSince.IsZero():
+		m.unavailableSince = at
+	case ready && !m.unavailableSince.IsZero():
+		m.unavailable += at.Sub(m.unavailableSince)
+		m.unavailableSince = time.Time{}
+	}
+}
+
+// total returns the time spent unavailable up to at, including an ongoing outage
+func (m *availabilityMonitor) total(at time.Time) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.unavailableSince.IsZero() {
+		return m.unavailable
+	}
+	return m.unavailable + at.Sub(m.unavailableSince)
+}
+
+// operandReady tells if the operand currently reports a readiness condition for its latest generation
+func operandReady(ctx context.Context, options *auditOptions, operand unstructured.Unstructured) (bool, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(operand.GroupVersionKind())
+	if err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), obj); err != nil {
+		return false, err
+	}
+	return readOperandStatus(*obj).Passed, nil
+}
+
+// waitForPodReplaced waits until the evicted pod is gone or was recreated under the same name
+func waitForPodReplaced(ctx context.Context, options *auditOptions, pod unstructured.Unstructured) error {
+	err := wait.PollImmediateWithContext(ctx, time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(pod.GroupVersionKind())
+		err := options.client.GetUnstructured(ctx, pod.GetNamespace(), pod.GetName(), current)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return current.GetUID() != pod.GetUID(), nil
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return err
+	}
+	return nil
+}
+
+// evictPod evicts a pod through the Eviction API. Evictions refused because they would violate a
+// PodDisruptionBudget are recorded as blocked.
+func evictPod(ctx context.Context, clientset kubernetes.Interface, pod unstructured.Unstructured) (report.PodEviction, error) {
+	eviction := report.PodEviction{Pod: pod.GetName()}
+
+	err := clientset.PolicyV1().Evictions(pod.GetNamespace()).Evict(ctx, &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.GetName(),
+			Namespace: pod.GetNamespace(),
+		},
+	})
+	switch {
+	case err == nil:
+		eviction.Evicted = true
+	case apierrors.IsTooManyRequests(err):
+		// the eviction would violate a PodDisruptionBudget
+		eviction.Blocked = true
+	default:
+		return eviction, fmt.Errorf("could not evict pod %s: %v", pod.GetName(), err)
+	}
+
+	return eviction, nil
+}
+
+// drainPods evicts pods back to back, without waiting for the replacement of the previous pod to become ready,
+// so that only a PodDisruptionBudget can keep the operand available. It stops at the first eviction blocked by a
+// PodDisruptionBudget and returns the evicted pods.
+func drainPods(ctx context.Context, clientset kubernetes.Interface, pods []unstructured.Unstructured, disruption *report.OperandDisruption) ([]unstructured.Unstructured, error) {
+	evicted := []unstructured.Unstructured{}
+	for _, pod := range pods {
+		if pod.GetKind() != "Pod" || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		eviction, err := evictPod(ctx, clientset, pod)
+		disruption.Evictions = append(disruption.Evictions, eviction)
+		if err != nil {
+			return evicted, err
+		}
+		if eviction.Blocked {
+			disruption.PDBBlocked = true
+			break
+		}
+		evicted = append(evicted, pod)
+	}
+	return evicted, nil
+}
+
+// disruptOperand drains the pods owned by operand, the way a node drain does, while watching the operand's
+// readiness condition, and then waits for the operand to recover
+func disruptOperand(ctx context.Context, options *auditOptions, clientset kubernetes.Interface, operand unstructured.Unstructured) (report.OperandDisruption, error) {
+	disruption := report.OperandDisruption{
+		Kind: operand.GetKind(),
+		Name: operand.GetName(),
+	}
+
+	health, err := waitForOperandHealth(ctx, options, operand)
+	if err != nil {
+		return disruption, err
+	}
+	disruption.Ready, err = operandReady(ctx, options, operand)
+	if err != nil {
+		return disruption, err
+	}
+	if !health.Healthy || !disruption.Ready {
+		logger.Infow("skipping disruption since operand is not ready", "kind", operand.GetKind(), "name", operand.GetName())
+		disruption.Ready = false
+		return disruption, nil
+	}
+
+	owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, workloadKinds)
+	if err != nil {
+		return disruption, err
+	}
+
+	monitor := &availabilityMonitor{}
+	monitorCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait.UntilWithContext(monitorCtx, func(ctx context.Context) {
+			ready, err := operandReady(ctx, options, operand)
+			if err != nil {
+				logger.Debugw("could not read operand readiness", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+				return
+			}
+			monitor.observe(ready, time.Now())
+		}, time.Second)
+	}()
+	defer func() {
+		stop()
+		<-done
+	}()
+
+	evicted, err := drainPods(ctx, clientset, owned, &disruption)
+	if err != nil {
+		return disruption, err
+	}
+
+	for _, pod := range evicted {
+		if err := waitForPodReplaced(ctx, options, pod); err != nil {
+			return disruption, err
+		}
+	}
+	health, err = waitForOperandHealth(ctx, options, operand)
+	if err != nil {
+		return disruption, err
+	}
+	disruption.Recovered = health.Healthy
+
+	disruption.Unavailable = monitor.total(time.Now()).Round(time.Second)
+	disruption.AvailabilityDropped = disruption.Unavailable > 0
+
+	return disruption, nil
+}
+
+// operandDisruption evicts the pods of each operand created by OperandInstall and checks that PodDisruptionBudgets
+// keep the operand ready during the disruption
+func operandDisruption(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("disrupting operands for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandDisruption") {
+			return nil
+		}
+
+		clientset, err := k8sClientset()
+		if err != nil {
+			return fmt.Errorf("could not get clientset: %v", err)
+		}
+
+		disruptions := []report.OperandDisruption{}
+		for _, operand := range *options.operands {
+			disruption, err := disruptOperand(ctx, &options, clientset, operand)
+			if err != nil {
+				logger.Errorw("could not disrupt operand", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+			}
+			disruptions = append(disruptions, disruption)
+		}
+
+		return writeReports(&options, "operand_disruption", report.TemplateData{
+			OperandDisruption: disruptions,
+		}, report.OperandDisruptionJsonReport, report.OperandDisruptionTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,116 @@
+package capability
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/report"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("Operand disruption", func() {
+	When("monitoring operand availability", func() {
+		var monitor *availabilityMonitor
+		var start time.Time
+
+		BeforeEach(func() {
+			monitor = &availabilityMonitor{}
+			start = time.Now()
+		})
+		It("should not count time while the operand is ready", func() {
+			monitor.observe(true, start)
+			monitor.observe(true, start.Add(time.Minute))
+			Expect(monitor.total(start.Add(2 * time.Minute))).To(BeZero())
+		})
+		It("should add up every outage", func() {
+			monitor.observe(false, start)
+			monitor.observe(true, start.Add(10*time.Second))
+			monitor.observe(false, start.Add(time.Minute))
+			monitor.observe(false, start.Add(time.Minute+5*time.Second))
+			monitor.observe(true, start.Add(time.Minute+20*time.Second))
+			Expect(monitor.total(start.Add(2 * time.Minute))).To(Equal(30 * time.Second))
+		})
+		It("should count an ongoing outage", func() {
+			monitor.observe(false, start)
+			Expect(monitor.total(start.Add(15 * time.Second))).To(Equal(15 * time.Second))
+		})
+	})
+	When("a PodDisruptionBudget refuses the eviction", func() {
+		It("should record the eviction as blocked", func() {
+			clientset := fake.NewSimpleClientset()
+			clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			})
+			pod := newOwnedObject("Pod", "test-pod", "pod-uid", "operand-uid")
+
+			eviction, err := evictPod(context.Background(), clientset, pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(eviction.Pod).To(Equal("test-pod"))
+			Expect(eviction.Blocked).To(BeTrue())
+			Expect(eviction.Evicted).To(BeFalse())
+		})
+	})
+	When("draining the operand pods", func() {
+		var clientset *fake.Clientset
+		var evictions []string
+		var pods []unstructured.Unstructured
+
+		BeforeEach(func() {
+			evictions = []string{}
+			clientset = fake.NewSimpleClientset()
+			clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				name := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction).Name
+				evictions = append(evictions, name)
+				// the budget allows a single disruption at a time
+				if len(evictions) > 1 {
+					return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+				}
+				return true, nil, nil
+			})
+			pods = []unstructured.Unstructured{
+				newOwnedObject("Deployment", "test", "deployment-uid", "operand-uid"),
+				newOwnedObject("Pod", "first", "first-uid", "deployment-uid"),
+				newOwnedObject("Pod", "second", "second-uid", "deployment-uid"),
+				newOwnedObject("Pod", "third", "third-uid", "deployment-uid"),
+			}
+		})
+		It("should evict the next pod right away and stop once a PodDisruptionBudget blocks", func() {
+			disruption := report.OperandDisruption{}
+			evicted, err := drainPods(context.Background(), clientset, pods, &disruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(evictions).To(Equal([]string{"first", "second"}))
+			Expect(evicted).To(HaveLen(1))
+			Expect(evicted[0].GetName()).To(Equal("first"))
+			Expect(disruption.PDBBlocked).To(BeTrue())
+			Expect(disruption.Evictions).To(Equal([]report.PodEviction{
+				{Pod: "first", Evicted: true},
+				{Pod: "second", Blocked: true},
+			}))
+		})
+		It("should evict every pod when nothing blocks the drain", func() {
+			clientset = fake.NewSimpleClientset()
+			clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return action.GetSubresource() == "eviction", nil, nil
+			})
+			disruption := report.OperandDisruption{}
+			evicted, err := drainPods(context.Background(), clientset, pods, &disruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(evicted).To(HaveLen(3))
+			Expect(disruption.PDBBlocked).To(BeFalse())
+		})
+	})
+})
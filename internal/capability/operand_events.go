@@ -0,0 +1,136 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// clusterEventSources are the components of Kubernetes, OpenShift and OLM that report events. Operators name their
+// event recorders freely, so any other component reporting events for an operand is taken as the operator.
+var clusterEventSources = map[string]bool{
+	"kubelet":                      true,
+	"default-scheduler":            true,
+	"kube-controller-manager":      true,
+	"deployment-controller":        true,
+	"replicaset-controller":        true,
+	"statefulset-controller":       true,
+	"daemonset-controller":         true,
+	"job-controller":               true,
+	"cronjob-controller":           true,
+	"endpoint-controller":          true,
+	"endpoint-slice-controller":    true,
+	"persistentvolume-controller":  true,
+	"horizontal-pod-autoscaler":    true,
+	"garbage-collector-controller": true,
+	"taint-controller":             true,
+	"service-controller":           true,
+	"route-controller-manager":     true,
+	"operator-lifecycle-manager":   true,
+	"catalog-operator":             true,
+}
+
+// eventSource returns the component that reported event, from the events.k8s.io reportingController when set
+func eventSource(event corev1.Event) string {
+	if event.ReportingController != "" {
+		return event.ReportingController
+	}
+	return event.Source.Component
+}
+
+// operatorEmitted tells if event was reported by the operator rather than by a cluster component
+func operatorEmitted(event corev1.Event) bool {
+	source := eventSource(event)
+	return source != "" && !clusterEventSources[source]
+}
+
+// countEvents counts events by the value returned by key, sorted by that value
+func countEvents(events []corev1.Event, key func(corev1.Event) string) []report.EventCount {
+	counts := map[string]int{}
+	for _, event := range events {
+		counts[key(event)]++
+	}
+
+	eventCounts := []report.EventCount{}
+	for name, count := range counts {
+		eventCounts = append(eventCounts, report.EventCount{Name: name, Count: count})
+	}
+	sort.Slice(eventCounts, func(i, j int) bool {
+		return eventCounts[i].Name < eventCounts[j].Name
+	})
+	return eventCounts
+}
+
+// classifyEvents keeps the events whose involved object is the operand, oldest first, and counts them by type
+// and by reason. The operand passes when at least one of them was emitted by the operator.
+func classifyEvents(operand unstructured.Unstructured, events []corev1.Event) report.OperandEvents {
+	operandEvents := report.OperandEvents{
+		Kind: operand.GetKind(),
+		Name: operand.GetName(),
+	}
+
+	matching := []corev1.Event{}
+	for _, event := range events {
+		if event.InvolvedObject.UID != operand.GetUID() {
+			continue
+		}
+		matching = append(matching, event)
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].CreationTimestamp.Before(&matching[j].CreationTimestamp)
+	})
+
+	for _, event := range matching {
+		operandEvents.Events = append(operandEvents.Events, reportEvent(event))
+		if operatorEmitted(event) {
+			operandEvents.OperatorEvents++
+		}
+	}
+	operandEvents.Passed = operandEvents.OperatorEvents > 0
+	operandEvents.Types = countEvents(matching, func(event corev1.Event) string { return event.Type })
+	operandEvents.Reasons = countEvents(matching, func(event corev1.Event) string { return event.Reason })
+
+	return operandEvents
+}
+
+// operandEvents gathers the events emitted for each operand created by OperandInstall. Operators that don't emit
+// custom events for their custom resources fail this audit, whatever the events reported by cluster components.
+func operandEvents(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("gathering operand events for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandEvents") {
+			return nil
+		}
+
+		clientset, err := k8sClientset()
+		if err != nil {
+			return fmt.Errorf("could not get clientset: %v", err)
+		}
+
+		events := []report.OperandEvents{}
+		for _, operand := range *options.operands {
+			list, err := EventsByNameAndKind(ctx, clientset, operand.GetName(), operand.GetKind(), operand.GetNamespace())
+			if err != nil {
+				logger.Errorw("could not list operand events", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+				list = &corev1.EventList{}
+			}
+			events = append(events, classifyEvents(operand, list.Items))
+		}
+
+		return writeReports(&options, "operand_events", report.TemplateData{
+			OperandEvents: events,
+		}, report.OperandEventsJsonReport, report.OperandEventsTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,80 @@
+package capability
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newEvent(uid types.UID, eventType, reason string, created time.Time) corev1.Event {
+	return corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		InvolvedObject: corev1.ObjectReference{UID: uid, Kind: "Owner", Name: "operand"},
+		Source:         corev1.EventSource{Component: "operand-controller"},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        `reconciled "operand"`,
+	}
+}
+
+var _ = Describe("Operand events", func() {
+	When("classifying events", func() {
+		It("should keep the operand events, oldest first, and count them", func() {
+			operand := newOwnedObject("Owner", "operand", "operand-uid", "")
+			start := time.Now()
+			events := []corev1.Event{
+				newEvent("operand-uid", corev1.EventTypeWarning, "Failed", start.Add(time.Minute)),
+				newEvent("other-uid", corev1.EventTypeNormal, "Created", start),
+				newEvent("operand-uid", corev1.EventTypeNormal, "Reconciled", start),
+				newEvent("operand-uid", corev1.EventTypeNormal, "Reconciled", start.Add(2*time.Minute)),
+			}
+
+			operandEvents := classifyEvents(operand, events)
+			Expect(operandEvents.Kind).To(Equal("Owner"))
+			Expect(operandEvents.Name).To(Equal("operand"))
+			Expect(operandEvents.Events).To(HaveLen(3))
+			Expect(operandEvents.Events[0].Reason).To(Equal("Reconciled"))
+			Expect(operandEvents.Events[1].Reason).To(Equal("Failed"))
+			Expect(operandEvents.Events[0].Message).To(Equal("reconciled operand"))
+			Expect(operandEvents.Types).To(Equal([]report.EventCount{{Name: "Normal", Count: 2}, {Name: "Warning", Count: 1}}))
+			Expect(operandEvents.Reasons).To(Equal([]report.EventCount{{Name: "Failed", Count: 1}, {Name: "Reconciled", Count: 2}}))
+			Expect(operandEvents.Events[0].Source).To(Equal("operand-controller"))
+			Expect(operandEvents.OperatorEvents).To(Equal(3))
+			Expect(operandEvents.Passed).To(BeTrue())
+		})
+		It("should only pass on events emitted by the operator", func() {
+			operand := newOwnedObject("Owner", "operand", "operand-uid", "")
+			gc := newEvent("operand-uid", corev1.EventTypeNormal, "OwnerRefInvalidNamespace", time.Now())
+			gc.Source = corev1.EventSource{Component: "garbage-collector-controller"}
+			olm := newEvent("operand-uid", corev1.EventTypeNormal, "Created", time.Now())
+			olm.Source = corev1.EventSource{}
+			olm.ReportingController = "operator-lifecycle-manager"
+			anonymous := newEvent("operand-uid", corev1.EventTypeNormal, "Created", time.Now())
+			anonymous.Source = corev1.EventSource{}
+
+			operandEvents := classifyEvents(operand, []corev1.Event{gc, olm, anonymous})
+			Expect(operandEvents.Events).To(HaveLen(3))
+			Expect(operandEvents.OperatorEvents).To(BeZero())
+			Expect(operandEvents.Passed).To(BeFalse())
+
+			operator := newEvent("operand-uid", corev1.EventTypeNormal, "Reconciled", time.Now())
+			operator.Source = corev1.EventSource{}
+			operator.ReportingController = "example.com/operand-controller"
+			operandEvents = classifyEvents(operand, []corev1.Event{gc, olm, anonymous, operator})
+			Expect(operandEvents.OperatorEvents).To(Equal(1))
+			Expect(operandEvents.Passed).To(BeTrue())
+		})
+		It("should find nothing for an operator that emits no events", func() {
+			operand := newOwnedObject("Owner", "operand", "operand-uid", "")
+			operandEvents := classifyEvents(operand, []corev1.Event{newEvent("other-uid", corev1.EventTypeNormal, "Created", time.Now())})
+			Expect(operandEvents.Events).To(BeEmpty())
+			Expect(operandEvents.Types).To(BeEmpty())
+			Expect(operandEvents.Passed).To(BeFalse())
+		})
+	})
+})
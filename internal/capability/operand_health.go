@@ -0,0 +1,105 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// waitForOperandHealth polls the resources owned by operand until all of them are ready or the audit times out.
+// Resources are re-listed on every poll since the operator may still be creating them. Operands that still own no
+// workloads when the audit times out are reported as such, rather than as unhealthy.
+func waitForOperandHealth(ctx context.Context, options *auditOptions, operand unstructured.Unstructured) (report.OperandHealth, error) {
+	start := time.Now()
+	readyAt := map[string]time.Duration{}
+	health := report.OperandHealth{
+		Kind: operand.GetKind(),
+		Name: operand.GetName(),
+	}
+
+	err := wait.PollImmediateWithContext(ctx, 5*time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, workloadKinds)
+		if err != nil {
+			return false, err
+		}
+
+		resources := []report.ResourceHealth{}
+		for _, obj := range owned {
+			// ReplicaSets are only listed to trace Pods back to Deployments
+			if obj.GetKind() == "ReplicaSet" {
+				continue
+			}
+
+			key := strings.Join([]string{obj.GetKind(), obj.GetName()}, "/")
+			if _, ok := readyAt[key]; !ok {
+				ready, err := resourceReady(obj)
+				if err != nil {
+					return false, fmt.Errorf("could not read status of %s: %v", key, err)
+				}
+				if ready {
+					readyAt[key] = time.Since(start).Round(time.Second)
+				}
+			}
+
+			timeToReady, ready := readyAt[key]
+			resources = append(resources, report.ResourceHealth{
+				Kind:        obj.GetKind(),
+				Name:        obj.GetName(),
+				Ready:       ready,
+				TimeToReady: timeToReady,
+			})
+		}
+		health.Resources = resources
+
+		for _, resource := range resources {
+			if !resource.Ready {
+				return false, nil
+			}
+		}
+		return len(resources) > 0, nil
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return health, err
+	}
+	health.Healthy = err == nil
+	health.NoWorkloads = !health.Healthy && len(health.Resources) == 0
+
+	return health, nil
+}
+
+// operandHealth waits for the workloads owned by each operand created by OperandInstall to become ready
+func operandHealth(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking operand health for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandHealth") {
+			return nil
+		}
+
+		operandHealth := []report.OperandHealth{}
+		for _, operand := range *options.operands {
+			health, err := waitForOperandHealth(ctx, &options, operand)
+			if err != nil {
+				logger.Errorw("could not check operand health", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+			}
+			operandHealth = append(operandHealth, health)
+		}
+
+		return writeReports(&options, "operand_health", report.TemplateData{
+			OperandHealth: operandHealth,
+		}, report.OperandHealthJsonReport, report.OperandHealthTextReport)
+	}, noCleanup
+}
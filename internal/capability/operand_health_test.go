@@ -0,0 +1,116 @@
+package capability
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Operand health", func() {
+	Context("resourceReady", func() {
+		DescribeTable("workload readiness",
+			func(kind string, fields map[string]interface{}, expected bool) {
+				obj := newOwnedObject(kind, "test", "uid", "operand-uid")
+				for path, value := range fields {
+					Expect(unstructured.SetNestedField(obj.Object, value, strings.Split(path, ".")...)).To(Succeed())
+				}
+				Expect(resourceReady(obj)).To(Equal(expected))
+			},
+			Entry("a statefulset with all replicas ready", "StatefulSet", map[string]interface{}{"spec.replicas": int64(3), "status.readyReplicas": int64(3)}, true),
+			Entry("a statefulset with missing replicas", "StatefulSet", map[string]interface{}{"spec.replicas": int64(3), "status.readyReplicas": int64(2)}, false),
+			Entry("a deployment whose rollout was not observed yet", "Deployment", map[string]interface{}{"metadata.generation": int64(2), "status.observedGeneration": int64(1), "status.updatedReplicas": int64(1), "status.availableReplicas": int64(1)}, false),
+			Entry("a daemonset ready on every node", "DaemonSet", map[string]interface{}{"status.desiredNumberScheduled": int64(2), "status.numberReady": int64(2)}, true),
+			Entry("a daemonset not ready on every node", "DaemonSet", map[string]interface{}{"status.desiredNumberScheduled": int64(2), "status.numberReady": int64(1)}, false),
+			Entry("a succeeded pod", "Pod", map[string]interface{}{"status.phase": "Succeeded"}, true),
+			Entry("a pending PVC", "PersistentVolumeClaim", map[string]interface{}{"status.phase": "Pending"}, false),
+		)
+		It("should read job completion from its conditions", func() {
+			obj := newOwnedObject("Job", "test", "uid", "operand-uid")
+			conditions := []interface{}{map[string]interface{}{"type": "Complete", "status": "True"}}
+			Expect(unstructured.SetNestedSlice(obj.Object, conditions, "status", "conditions")).To(Succeed())
+			Expect(resourceReady(obj)).To(BeTrue())
+		})
+	})
+
+	Context("waitForOperandHealth", func() {
+		var operand unstructured.Unstructured
+		var options *auditOptions
+
+		ownedDeployment := func(available int32) *appsv1.Deployment {
+			replicas := int32(1)
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "testns",
+					Name:            "testdeployment",
+					UID:             "deployment-uid",
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "Owner", Name: "owner", UID: "operand-uid"}},
+				},
+				Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+				Status: appsv1.DeploymentStatus{UpdatedReplicas: available, AvailableReplicas: available},
+			}
+		}
+
+		BeforeEach(func() {
+			operand = newOwnedObject("Owner", "owner", "operand-uid", "")
+			operand.SetNamespace("testns")
+			options = &auditOptions{namespace: "testns", csvWaitTime: time.Millisecond}
+		})
+		It("should be healthy once the owned workloads are ready", func() {
+			options.client = operator.NewFakeOpClient(
+				ownedDeployment(1),
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "unrelated", UID: "unrelated-uid"}},
+			)
+			health, err := waitForOperandHealth(context.TODO(), options, operand)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Healthy).To(BeTrue())
+			Expect(health.NoWorkloads).To(BeFalse())
+			Expect(health.Resources).To(HaveLen(1))
+			Expect(health.Resources[0].Name).To(Equal("testdeployment"))
+			Expect(health.Resources[0].Ready).To(BeTrue())
+		})
+		It("should be unhealthy when an owned workload never becomes ready", func() {
+			options.client = operator.NewFakeOpClient(ownedDeployment(0))
+			health, err := waitForOperandHealth(context.TODO(), options, operand)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Healthy).To(BeFalse())
+			Expect(health.Resources).To(HaveLen(1))
+			Expect(health.Resources[0].Ready).To(BeFalse())
+		})
+		It("should wait for the operator to create workloads before reporting none", func() {
+			options.client = operator.NewFakeOpClient()
+			options.csvWaitTime = 6 * time.Second
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ownedDeployment(1))
+			Expect(err).ToNot(HaveOccurred())
+			deployment := &unstructured.Unstructured{Object: content}
+			deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(time.Second)
+				Expect(options.client.CreateUnstructured(context.TODO(), deployment)).To(Succeed())
+			}()
+			health, err := waitForOperandHealth(context.TODO(), options, operand)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Healthy).To(BeTrue())
+			Expect(health.NoWorkloads).To(BeFalse())
+			Expect(health.Resources).To(HaveLen(1))
+		})
+		It("should report an operand still without owned workloads once the wait times out", func() {
+			options.client = operator.NewFakeOpClient()
+			health, err := waitForOperandHealth(context.TODO(), options, operand)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.NoWorkloads).To(BeTrue())
+			Expect(health.Healthy).To(BeFalse())
+			Expect(health.Resources).To(BeEmpty())
+		})
+	})
+})
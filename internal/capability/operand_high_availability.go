@@ -0,0 +1,158 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// highAvailabilityKinds are the owned kinds checked by the OperandHighAvailability audit
+var highAvailabilityKinds = append(append([]schema.GroupVersionKind{}, workloadKinds...),
+	policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"))
+
+// matchingDisruptionBudget returns the name of the first PodDisruptionBudget selecting pods with podLabels
+func matchingDisruptionBudget(pdbs []policyv1.PodDisruptionBudget, podLabels map[string]string) string {
+	for _, pdb := range pdbs {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			return pdb.Name
+		}
+	}
+	return ""
+}
+
+// inspectWorkloadAvailability checks a workload with more than one replica for a PodDisruptionBudget, pod
+// anti-affinity or topology spread constraints and a rolling update strategy
+func inspectWorkloadAvailability(kind, name string, replicas int32, template corev1.PodTemplateSpec, rollingUpdate bool, pdbs []policyv1.PodDisruptionBudget) report.WorkloadAvailability {
+	availability := report.WorkloadAvailability{
+		Kind:          kind,
+		Name:          name,
+		Replicas:      replicas,
+		RollingUpdate: rollingUpdate,
+	}
+	if replicas <= 1 {
+		return availability
+	}
+
+	availability.PodDisruptionBudget = matchingDisruptionBudget(pdbs, template.Labels)
+	if affinity := template.Spec.Affinity; affinity != nil && affinity.PodAntiAffinity != nil {
+		availability.AntiAffinity = len(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) > 0 ||
+			len(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) > 0
+	}
+	availability.TopologySpread = len(template.Spec.TopologySpreadConstraints) > 0
+
+	if availability.PodDisruptionBudget == "" {
+		availability.Findings = append(availability.Findings, "no PodDisruptionBudget")
+	}
+	if !availability.AntiAffinity && !availability.TopologySpread {
+		availability.Findings = append(availability.Findings, "no pod anti-affinity or topology spread constraints")
+	}
+	if !availability.RollingUpdate {
+		availability.Findings = append(availability.Findings, "no rolling update strategy")
+	}
+
+	return availability
+}
+
+// checkOperandAvailability inspects the Deployments and StatefulSets owned by an operand
+func checkOperandAvailability(ctx context.Context, options *auditOptions, operand unstructured.Unstructured) (report.OperandHighAvailability, error) {
+	highAvailability := report.OperandHighAvailability{
+		Kind: operand.GetKind(),
+		Name: operand.GetName(),
+	}
+
+	owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, highAvailabilityKinds)
+	if err != nil {
+		return highAvailability, err
+	}
+
+	pdbs := []policyv1.PodDisruptionBudget{}
+	for _, obj := range owned {
+		if obj.GetKind() != "PodDisruptionBudget" {
+			continue
+		}
+		var pdb policyv1.PodDisruptionBudget
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pdb); err != nil {
+			return highAvailability, fmt.Errorf("could not read PodDisruptionBudget %s: %v", obj.GetName(), err)
+		}
+		pdbs = append(pdbs, pdb)
+	}
+
+	for _, obj := range owned {
+		switch obj.GetKind() {
+		case "Deployment":
+			var deployment appsv1.Deployment
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deployment); err != nil {
+				return highAvailability, fmt.Errorf("could not read Deployment %s: %v", obj.GetName(), err)
+			}
+			replicas := int32(1)
+			if deployment.Spec.Replicas != nil {
+				replicas = *deployment.Spec.Replicas
+			}
+			// an unset strategy defaults to RollingUpdate
+			rollingUpdate := deployment.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType
+			highAvailability.Workloads = append(highAvailability.Workloads,
+				inspectWorkloadAvailability(obj.GetKind(), obj.GetName(), replicas, deployment.Spec.Template, rollingUpdate, pdbs))
+
+		case "StatefulSet":
+			var statefulSet appsv1.StatefulSet
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &statefulSet); err != nil {
+				return highAvailability, fmt.Errorf("could not read StatefulSet %s: %v", obj.GetName(), err)
+			}
+			replicas := int32(1)
+			if statefulSet.Spec.Replicas != nil {
+				replicas = *statefulSet.Spec.Replicas
+			}
+			// an unset update strategy defaults to RollingUpdate
+			rollingUpdate := statefulSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType
+			highAvailability.Workloads = append(highAvailability.Workloads,
+				inspectWorkloadAvailability(obj.GetKind(), obj.GetName(), replicas, statefulSet.Spec.Template, rollingUpdate, pdbs))
+		}
+	}
+
+	return highAvailability, nil
+}
+
+// operandHighAvailability checks the workloads owned by each operand created by OperandInstall for the Level 3
+// high availability criteria
+func operandHighAvailability(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking operand high availability for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandHighAvailability") {
+			return nil
+		}
+
+		highAvailability := []report.OperandHighAvailability{}
+		for _, operand := range *options.operands {
+			availability, err := checkOperandAvailability(ctx, &options, operand)
+			if err != nil {
+				logger.Errorw("could not check operand high availability", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+			}
+			highAvailability = append(highAvailability, availability)
+		}
+
+		return writeReports(&options, "operand_high_availability", report.TemplateData{
+			OperandHighAvailability: highAvailability,
+		}, report.OperandHighAvailabilityJsonReport, report.OperandHighAvailabilityTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,60 @@
+package capability
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Operand high availability", func() {
+	var template corev1.PodTemplateSpec
+	var pdbs []policyv1.PodDisruptionBudget
+
+	BeforeEach(func() {
+		template = corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+		}
+		pdbs = []policyv1.PodDisruptionBudget{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}},
+			},
+		}
+	})
+
+	When("the workload has a single replica", func() {
+		It("should not report findings", func() {
+			availability := inspectWorkloadAvailability("Deployment", "test", 1, template, false, nil)
+			Expect(availability.Findings).To(BeEmpty())
+		})
+	})
+	When("the workload is spread and covered by a PodDisruptionBudget", func() {
+		It("should be highly available", func() {
+			template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{TopologyKey: "topology.kubernetes.io/zone"}}
+
+			availability := inspectWorkloadAvailability("Deployment", "test", 3, template, true, pdbs)
+			Expect(availability.PodDisruptionBudget).To(Equal("test"))
+			Expect(availability.TopologySpread).To(BeTrue())
+			Expect(availability.Findings).To(BeEmpty())
+		})
+	})
+	When("the workload has none of the high availability settings", func() {
+		It("should report a finding for each", func() {
+			template.Spec.Affinity = &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}}
+
+			availability := inspectWorkloadAvailability("StatefulSet", "test", 3, template, false, pdbs[:1])
+			Expect(availability.AntiAffinity).To(BeFalse())
+			Expect(availability.Findings).To(Equal([]string{
+				"no PodDisruptionBudget",
+				"no pod anti-affinity or topology spread constraints",
+				"no rolling update strategy",
+			}))
+		})
+	})
+})
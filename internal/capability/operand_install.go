@@ -88,7 +88,7 @@ func operandInstall(ctx context.Context, opts ...auditOption) (auditFn, auditCle
 				logger.Errorw("could not create resource", "error", err, "namespace", options.namespace)
 				continue
 			}
-			options.operands = append(options.operands, *obj)
+			*options.operands = append(*options.operands, *obj)
 		}
 
 		file, err := options.fs.OpenFile("operand_install_report.json", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
@@ -98,22 +98,24 @@ func operandInstall(ctx context.Context, opts ...auditOption) (auditFn, auditCle
 		defer file.Close()
 
 		err = report.OperandInstallJsonReport(file, report.TemplateData{
-			CustomResources: options.customResources,
-			OcpVersion:      options.ocpVersion,
-			Subscription:    *options.subscription,
-			Csv:             options.csv,
-			OperandCount:    len(options.operands),
+			CustomResources:       options.customResources,
+			OcpVersion:            options.ocpVersion,
+			Subscription:          *options.subscription,
+			Csv:                   options.csv,
+			OperandCount:          len(*options.operands),
+			RestrictedPodSecurity: options.restrictedPodSecurity,
 		})
 		if err != nil {
 			return fmt.Errorf("could not generate operand install JSON report: %v", err)
 		}
 
 		err = report.OperandInstallTextReport(options.reportWriter, report.TemplateData{
-			CustomResources: options.customResources,
-			OcpVersion:      options.ocpVersion,
-			Subscription:    *options.subscription,
-			Csv:             options.csv,
-			OperandCount:    len(options.operands),
+			CustomResources:       options.customResources,
+			OcpVersion:            options.ocpVersion,
+			Subscription:          *options.subscription,
+			Csv:                   options.csv,
+			OperandCount:          len(*options.operands),
+			RestrictedPodSecurity: options.restrictedPodSecurity,
 		})
 		if err != nil {
 			return fmt.Errorf("could not generate operand install text report: %v", err)
@@ -0,0 +1,253 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// mergeSpec deep merges src into dst. Values in src win over the ones in dst.
+func mergeSpec(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeSpec(dstMap, srcMap)
+			continue
+		}
+		dst[key] = runtime.DeepCopyJSONValue(value)
+	}
+}
+
+// applyUserChange merges the spec of the first user supplied partial CR matching the operand's kind,
+// and name when one is set, into obj. It returns the top level spec fields it changed.
+func applyUserChange(obj *unstructured.Unstructured, changes []map[string]interface{}) (string, [][]string, error) {
+	for _, change := range changes {
+		partial := unstructured.Unstructured{Object: change}
+		if partial.GetKind() != obj.GetKind() {
+			continue
+		}
+		if partial.GetName() != "" && partial.GetName() != obj.GetName() {
+			continue
+		}
+		changeSpec, found, err := unstructured.NestedMap(change, "spec")
+		if err != nil || !found {
+			continue
+		}
+
+		spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+		if err != nil {
+			return "", nil, err
+		}
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+		mergeSpec(spec, changeSpec)
+		if err := unstructured.SetNestedMap(obj.Object, spec, "spec"); err != nil {
+			return "", nil, err
+		}
+
+		names := []string{}
+		for field := range changeSpec {
+			names = append(names, field)
+		}
+		sort.Strings(names)
+		fields := [][]string{}
+		keys := []string{}
+		for _, name := range names {
+			fields = append(fields, []string{"spec", name})
+			keys = append(keys, "spec."+name)
+		}
+		return strings.Join(keys, ","), fields, nil
+	}
+
+	return "", nil, nil
+}
+
+// applyDescriptorChange bumps the first podCount field or flips the first booleanSwitch field
+// declared by the CSV spec descriptors for the operand's kind. It returns the field it changed.
+func applyDescriptorChange(obj *unstructured.Unstructured, csv *operatorv1alpha1.ClusterServiceVersion) (string, [][]string, error) {
+	for _, fields := range specDescriptorFields(csv, *obj, podCountDescriptor) {
+		count, found, err := unstructured.NestedInt64(obj.Object, fields...)
+		if err != nil {
+			continue
+		}
+		from := fmt.Sprint(count)
+		if !found {
+			count = 1
+			from = "unset"
+		}
+		if err := unstructured.SetNestedField(obj.Object, count+1, fields...); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s: %s -> %d", strings.Join(fields, "."), from, count+1), [][]string{fields}, nil
+	}
+
+	for _, fields := range specDescriptorFields(csv, *obj, booleanSwitchDescriptor) {
+		value, found, err := unstructured.NestedBool(obj.Object, fields...)
+		if err != nil {
+			continue
+		}
+		from := fmt.Sprint(value)
+		if !found {
+			from = "unset"
+		}
+		if err := unstructured.SetNestedField(obj.Object, !value, fields...); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s: %s -> %t", strings.Join(fields, "."), from, !value), [][]string{fields}, nil
+	}
+
+	return "", nil, nil
+}
+
+// restoreFields puts the values fields had in original back into operand, removing the ones that were unset, so
+// that the audits running later in the plan see the operand as OperandInstall created it
+func restoreFields(ctx context.Context, options *auditOptions, operand unstructured.Unstructured, original *unstructured.Unstructured, fields [][]string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(operand.GroupVersionKind())
+		if err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), obj); err != nil {
+			return err
+		}
+		for _, field := range fields {
+			value, found, err := unstructured.NestedFieldCopy(original.Object, field...)
+			if err != nil {
+				return err
+			}
+			if !found {
+				unstructured.RemoveNestedField(obj.Object, field...)
+				continue
+			}
+			if err := unstructured.SetNestedField(obj.Object, value, field...); err != nil {
+				return err
+			}
+		}
+		return options.client.UpdateUnstructured(ctx, obj)
+	})
+}
+
+// reconfigureOperand applies a spec change to operand, waits for the operator to reconcile it and then restores
+// the changed fields
+func reconfigureOperand(ctx context.Context, options *auditOptions, operand unstructured.Unstructured) (report.OperandReconfiguration, error) {
+	result := report.OperandReconfiguration{
+		Kind: operand.GetKind(),
+		Name: operand.GetName(),
+	}
+
+	before, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, ownedKinds)
+	if err != nil {
+		return result, err
+	}
+
+	obj := operand.DeepCopy()
+	var original *unstructured.Unstructured
+	var fields [][]string
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), obj); err != nil {
+			return err
+		}
+		original = obj.DeepCopy()
+
+		result.Source = "user"
+		result.Change, fields, err = applyUserChange(obj, options.operandChanges)
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			result.Source = "descriptor"
+			result.Change, fields, err = applyDescriptorChange(obj, options.csv)
+			if err != nil {
+				return err
+			}
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+
+		return options.client.UpdateUnstructured(ctx, obj)
+	})
+	if err != nil {
+		return result, fmt.Errorf("could not update operand: %v", err)
+	}
+	if len(fields) == 0 {
+		result.Source = ""
+		logger.Infow("no user supplied change or supported spec descriptor found for operand", "kind", operand.GetKind(), "name", operand.GetName())
+		return result, nil
+	}
+	result.Generation = obj.GetGeneration()
+
+	err = wait.PollImmediateWithContext(ctx, 5*time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		if err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), obj); err != nil {
+			return false, err
+		}
+		result.ObservedGeneration = readOperandStatus(*obj).ObservedGeneration
+
+		after, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, ownedKinds)
+		if err != nil {
+			return false, err
+		}
+		result.ChangedResources = changedResources(before, after)
+
+		return result.ObservedGeneration >= result.Generation && len(result.ChangedResources) > 0, nil
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return result, err
+	}
+	result.Passed = err == nil
+
+	if err := restoreFields(ctx, options, operand, original, fields); err != nil {
+		return result, fmt.Errorf("could not restore operand: %v", err)
+	}
+	result.Restored = true
+
+	return result, nil
+}
+
+// operandReconfiguration changes the spec of each operand created by OperandInstall and checks that
+// the operator reconciles the change into the resources it owns
+func operandReconfiguration(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("reconfiguring operands for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandReconfiguration") {
+			return nil
+		}
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+		options.csv = csv
+
+		reconfigurations := []report.OperandReconfiguration{}
+		for _, operand := range *options.operands {
+			result, err := reconfigureOperand(ctx, &options, operand)
+			if err != nil {
+				logger.Errorw("could not reconfigure operand", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+			}
+			reconfigurations = append(reconfigurations, result)
+		}
+
+		return writeReports(&options, "operand_reconfiguration", report.TemplateData{
+			OperandReconfiguration: reconfigurations,
+		}, report.OperandReconfigurationJsonReport, report.OperandReconfigurationTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,154 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Operand reconfiguration", func() {
+	var obj *unstructured.Unstructured
+
+	BeforeEach(func() {
+		obj = &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "test.opcap.io/v1",
+			"kind":       "TestKind",
+			"metadata": map[string]interface{}{
+				"name": "testname",
+			},
+			"spec": map[string]interface{}{
+				"size": int64(1),
+				"config": map[string]interface{}{
+					"logLevel": "info",
+					"debug":    false,
+				},
+			},
+		}}
+	})
+
+	Context("applyUserChange", func() {
+		When("a change matches the operand kind", func() {
+			It("should merge the change into the spec", func() {
+				change, fields, err := applyUserChange(obj, []map[string]interface{}{
+					{
+						"kind": "OtherKind",
+						"spec": map[string]interface{}{"size": int64(5)},
+					},
+					{
+						"kind": "TestKind",
+						"spec": map[string]interface{}{
+							"config": map[string]interface{}{"logLevel": "debug"},
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fields).To(Equal([][]string{{"spec", "config"}}))
+				Expect(change).To(Equal("spec.config"))
+				Expect(obj.Object["spec"]).To(Equal(map[string]interface{}{
+					"size": int64(1),
+					"config": map[string]interface{}{
+						"logLevel": "debug",
+						"debug":    false,
+					},
+				}))
+			})
+		})
+		When("no change matches the operand name", func() {
+			It("should not change the operand", func() {
+				_, fields, err := applyUserChange(obj, []map[string]interface{}{
+					{
+						"kind":     "TestKind",
+						"metadata": map[string]interface{}{"name": "othername"},
+						"spec":     map[string]interface{}{"size": int64(5)},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fields).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("applyDescriptorChange", func() {
+		var csv *operatorv1alpha1.ClusterServiceVersion
+
+		BeforeEach(func() {
+			csv = &operatorv1alpha1.ClusterServiceVersion{
+				Spec: operatorv1alpha1.ClusterServiceVersionSpec{
+					CustomResourceDefinitions: operatorv1alpha1.CustomResourceDefinitions{
+						Owned: []operatorv1alpha1.CRDDescription{
+							{
+								Name:    "testkinds.test.opcap.io",
+								Version: "v1",
+								Kind:    "TestKind",
+								SpecDescriptors: []operatorv1alpha1.SpecDescriptor{
+									{Path: "config.debug", XDescriptors: []string{booleanSwitchDescriptor}},
+									{Path: "size", XDescriptors: []string{podCountDescriptor}},
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		When("the CSV declares a podCount descriptor", func() {
+			It("should bump the pod count", func() {
+				change, fields, err := applyDescriptorChange(obj, csv)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fields).To(Equal([][]string{{"spec", "size"}}))
+				Expect(change).To(Equal("spec.size: 1 -> 2"))
+				Expect(obj.Object["spec"]).To(HaveKeyWithValue("size", int64(2)))
+			})
+		})
+		When("the CSV only declares a booleanSwitch descriptor", func() {
+			BeforeEach(func() {
+				csv.Spec.CustomResourceDefinitions.Owned[0].SpecDescriptors = csv.Spec.CustomResourceDefinitions.Owned[0].SpecDescriptors[:1]
+			})
+			It("should flip the switch", func() {
+				change, fields, err := applyDescriptorChange(obj, csv)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fields).To(Equal([][]string{{"spec", "config", "debug"}}))
+				Expect(change).To(Equal("spec.config.debug: false -> true"))
+			})
+		})
+		When("the CSV owns a different kind", func() {
+			BeforeEach(func() {
+				csv.Spec.CustomResourceDefinitions.Owned[0].Kind = "OtherKind"
+			})
+			It("should not change the operand", func() {
+				_, fields, err := applyDescriptorChange(obj, csv)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fields).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("restoreFields", func() {
+		It("should put back the original values and remove the fields that were unset", func() {
+			operand := &unstructured.Unstructured{}
+			operand.SetAPIVersion("v1")
+			operand.SetKind("ConfigMap")
+			operand.SetNamespace("testns")
+			operand.SetName("testname")
+			Expect(unstructured.SetNestedField(operand.Object, "1", "data", "size")).To(Succeed())
+			original := operand.DeepCopy()
+
+			Expect(unstructured.SetNestedField(operand.Object, "2", "data", "size")).To(Succeed())
+			Expect(unstructured.SetNestedField(operand.Object, "true", "data", "debug")).To(Succeed())
+			Expect(unstructured.SetNestedField(operand.Object, "kept", "data", "other")).To(Succeed())
+			options := &auditOptions{client: operator.NewFakeOpClient()}
+			Expect(options.client.CreateUnstructured(context.TODO(), operand)).To(Succeed())
+
+			Expect(restoreFields(context.TODO(), options, *operand, original, [][]string{{"data", "size"}, {"data", "debug"}})).To(Succeed())
+
+			restored := &unstructured.Unstructured{}
+			restored.SetGroupVersionKind(operand.GroupVersionKind())
+			Expect(options.client.GetUnstructured(context.TODO(), "testns", "testname", restored)).To(Succeed())
+			Expect(restored.Object["data"]).To(Equal(map[string]interface{}{"size": "1", "other": "kept"}))
+		})
+	})
+})
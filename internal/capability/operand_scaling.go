@@ -0,0 +1,247 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// workloadReplicas returns the desired and ready replicas of the Deployments and StatefulSets owned by an operand
+func workloadReplicas(obj unstructured.Unstructured) (int32, int32, bool, error) {
+	switch obj.GetKind() {
+	case "Deployment":
+		var deployment appsv1.Deployment
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deployment); err != nil {
+			return 0, 0, false, err
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return replicas, deployment.Status.ReadyReplicas, true, nil
+
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &statefulSet); err != nil {
+			return 0, 0, false, err
+		}
+		replicas := int32(1)
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+		return replicas, statefulSet.Status.ReadyReplicas, true, nil
+	}
+
+	return 0, 0, false, nil
+}
+
+// workloadReplicaCounts maps the desired replicas of the Deployments and StatefulSets in owned by Kind/Name
+func workloadReplicaCounts(owned []unstructured.Unstructured) (map[string]int32, error) {
+	counts := map[string]int32{}
+	for _, obj := range owned {
+		desired, _, ok, err := workloadReplicas(obj)
+		if err != nil {
+			return nil, fmt.Errorf("could not read replicas of %s/%s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		if ok {
+			counts[strings.Join([]string{obj.GetKind(), obj.GetName()}, "/")] = desired
+		}
+	}
+	return counts, nil
+}
+
+// scaledWorkloads returns the owned workloads listed in expected that have both desired and ready replicas
+// matching their expected count
+func scaledWorkloads(owned []unstructured.Unstructured, expected map[string]int32) ([]string, error) {
+	scaled := []string{}
+	for _, obj := range owned {
+		key := strings.Join([]string{obj.GetKind(), obj.GetName()}, "/")
+		count, listed := expected[key]
+		if !listed {
+			continue
+		}
+		desired, ready, ok, err := workloadReplicas(obj)
+		if err != nil {
+			return nil, fmt.Errorf("could not read replicas of %s: %v", key, err)
+		}
+		if ok && desired == count && ready == count {
+			scaled = append(scaled, key)
+		}
+	}
+	sort.Strings(scaled)
+	return scaled, nil
+}
+
+// scaleOperand sets the podCount field of operand to replicas, or removes it when replicas is nil, and waits for
+// the owned workloads to reach their expected replicas. It waits for every expected workload when all is set, and
+// for any of them otherwise.
+func scaleOperand(ctx context.Context, options *auditOptions, operand unstructured.Unstructured, fields []string, replicas *int64, expected map[string]int32, all bool) (report.ScalingStep, error) {
+	step := report.ScalingStep{Unset: replicas == nil}
+	if replicas != nil {
+		step.Replicas = *replicas
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(operand.GroupVersionKind())
+		if err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), obj); err != nil {
+			return err
+		}
+		if replicas == nil {
+			unstructured.RemoveNestedField(obj.Object, fields...)
+		} else if err := unstructured.SetNestedField(obj.Object, *replicas, fields...); err != nil {
+			return err
+		}
+		return options.client.UpdateUnstructured(ctx, obj)
+	})
+	if err != nil {
+		return step, fmt.Errorf("could not scale operand: %v", err)
+	}
+	start := time.Now()
+
+	err = wait.PollImmediateWithContext(ctx, 5*time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, workloadKinds)
+		if err != nil {
+			return false, err
+		}
+		step.Workloads, err = scaledWorkloads(owned, expected)
+		if err != nil {
+			return false, err
+		}
+		if all {
+			return len(step.Workloads) == len(expected), nil
+		}
+		return len(step.Workloads) > 0, nil
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return step, err
+	}
+	step.Scaled = err == nil
+	step.Duration = time.Since(start).Round(time.Second)
+
+	return step, nil
+}
+
+// scaleOperandUpAndDown scales operand one replica up through the first podCount spec descriptor declared by
+// the CSV and restores the field to its original value afterwards. Scaling up passes when a workload that had
+// other replicas before the change follows the new count, and restoring passes when those workloads go back to
+// their original replicas.
+func scaleOperandUpAndDown(ctx context.Context, options *auditOptions, operand unstructured.Unstructured) (report.OperandScaling, error) {
+	scaling := report.OperandScaling{
+		Kind: operand.GetKind(),
+		Name: operand.GetName(),
+	}
+
+	descriptorFields := specDescriptorFields(options.csv, operand, podCountDescriptor)
+	if len(descriptorFields) == 0 {
+		logger.Infow("skipping scaling since the CSV declares no podCount spec descriptor for the operand", "kind", operand.GetKind(), "name", operand.GetName())
+		return scaling, nil
+	}
+	fields := descriptorFields[0]
+	scaling.Field = strings.Join(fields, ".")
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(operand.GroupVersionKind())
+	if err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), current); err != nil {
+		return scaling, fmt.Errorf("could not get operand: %v", err)
+	}
+	original, found, err := unstructured.NestedInt64(current.Object, fields...)
+	if err != nil {
+		return scaling, fmt.Errorf("could not read %s: %v", scaling.Field, err)
+	}
+	count := original
+	if !found || count < 1 {
+		count = 1
+	}
+
+	owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, workloadKinds)
+	if err != nil {
+		return scaling, err
+	}
+	before, err := workloadReplicaCounts(owned)
+	if err != nil {
+		return scaling, err
+	}
+
+	// only workloads that didn't already run the new count can show that the operand was scaled
+	up := count + 1
+	expected := map[string]int32{}
+	for key, replicas := range before {
+		if int64(replicas) != up {
+			expected[key] = int32(up)
+		}
+	}
+	step, err := scaleOperand(ctx, options, operand, fields, &up, expected, false)
+	scaling.Steps = append(scaling.Steps, step)
+	if err != nil {
+		return scaling, err
+	}
+
+	// restore the original value, or remove the field when it wasn't set, and expect the scaled workloads to go
+	// back to their replicas from before the change
+	var restore *int64
+	if found {
+		restore = &original
+	}
+	expected = map[string]int32{}
+	for _, key := range step.Workloads {
+		expected[key] = before[key]
+	}
+	step, err = scaleOperand(ctx, options, operand, fields, restore, expected, true)
+	scaling.Steps = append(scaling.Steps, step)
+	if err != nil {
+		return scaling, err
+	}
+
+	scaling.Passed = scaling.Steps[0].Scaled && scaling.Steps[1].Scaled
+
+	return scaling, nil
+}
+
+// operandScaling scales each operand created by OperandInstall through its podCount spec descriptor and checks
+// that the owned workloads follow
+func operandScaling(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("scaling operands for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandScaling") {
+			return nil
+		}
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+		options.csv = csv
+
+		operandScaling := []report.OperandScaling{}
+		for _, operand := range *options.operands {
+			scaling, err := scaleOperandUpAndDown(ctx, &options, operand)
+			if err != nil {
+				logger.Errorw("could not scale operand", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+			}
+			operandScaling = append(operandScaling, scaling)
+		}
+
+		return writeReports(&options, "operand_scaling", report.TemplateData{
+			OperandScaling: operandScaling,
+		}, report.OperandScalingJsonReport, report.OperandScalingTextReport)
+	}, noCleanup
+}
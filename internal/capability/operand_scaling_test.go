@@ -0,0 +1,77 @@
+package capability
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Operand scaling", func() {
+	Context("scaledWorkloads", func() {
+		It("should only return expected workloads with matching desired and ready replicas", func() {
+			deployment := newOwnedObject("Deployment", "scaled", "deployment-uid", "operand-uid")
+			Expect(unstructured.SetNestedField(deployment.Object, int64(2), "spec", "replicas")).To(Succeed())
+			Expect(unstructured.SetNestedField(deployment.Object, int64(2), "status", "readyReplicas")).To(Succeed())
+
+			statefulSet := newOwnedObject("StatefulSet", "scaling", "statefulset-uid", "operand-uid")
+			Expect(unstructured.SetNestedField(statefulSet.Object, int64(2), "spec", "replicas")).To(Succeed())
+			Expect(unstructured.SetNestedField(statefulSet.Object, int64(1), "status", "readyReplicas")).To(Succeed())
+
+			// Deployments without replicas default to one
+			defaulted := newOwnedObject("Deployment", "defaulted", "defaulted-uid", "operand-uid")
+			Expect(unstructured.SetNestedField(defaulted.Object, int64(1), "status", "readyReplicas")).To(Succeed())
+
+			owned := []unstructured.Unstructured{deployment, statefulSet, defaulted, newOwnedObject("Pod", "pod", "pod-uid", "operand-uid")}
+
+			Expect(workloadReplicaCounts(owned)).To(Equal(map[string]int32{
+				"Deployment/scaled":    2,
+				"StatefulSet/scaling":  2,
+				"Deployment/defaulted": 1,
+			}))
+			Expect(scaledWorkloads(owned, map[string]int32{"Deployment/scaled": 2, "StatefulSet/scaling": 2})).To(Equal([]string{"Deployment/scaled"}))
+			Expect(scaledWorkloads(owned, map[string]int32{"Deployment/defaulted": 1})).To(Equal([]string{"Deployment/defaulted"}))
+			// workloads that already ran the count before the change are not expected to scale
+			Expect(scaledWorkloads(owned, map[string]int32{})).To(BeEmpty())
+		})
+	})
+
+	Context("scaleOperand", func() {
+		var options *auditOptions
+		var operand unstructured.Unstructured
+
+		BeforeEach(func() {
+			operand = unstructured.Unstructured{}
+			operand.SetAPIVersion("v1")
+			operand.SetKind("ConfigMap")
+			operand.SetNamespace("testns")
+			operand.SetName("operand")
+			options = &auditOptions{client: operator.NewFakeOpClient(), csvWaitTime: time.Millisecond}
+			Expect(unstructured.SetNestedField(operand.Object, "3", "data", "size")).To(Succeed())
+			Expect(options.client.CreateUnstructured(context.TODO(), &operand)).To(Succeed())
+		})
+		It("should remove the field when restoring an unset value", func() {
+			step, err := scaleOperand(context.TODO(), options, operand, []string{"data", "size"}, nil, map[string]int32{}, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(step.Unset).To(BeTrue())
+			Expect(step.Scaled).To(BeTrue())
+
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(operand.GroupVersionKind())
+			Expect(options.client.GetUnstructured(context.TODO(), "testns", "operand", obj)).To(Succeed())
+			_, found, err := unstructured.NestedFieldNoCopy(obj.Object, "data", "size")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+		It("should not pass without a workload following the new count", func() {
+			step, err := scaleOperand(context.TODO(), options, operand, []string{"data", "other"}, nil, map[string]int32{"Deployment/operand": 2}, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(step.Scaled).To(BeFalse())
+			Expect(step.Workloads).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,193 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// selfHealingKinds are the owned kinds disturbed by the OperandSelfHealing audit, in the order they are deleted
+var selfHealingKinds = []string{"Deployment", "ConfigMap", "Service", "Pod"}
+
+// pickDisturbedResource returns the first owned object of kind that isn't already being deleted
+func pickDisturbedResource(owned []unstructured.Unstructured, kind string) (unstructured.Unstructured, bool) {
+	for _, obj := range owned {
+		if obj.GetKind() == kind && obj.GetDeletionTimestamp() == nil {
+			return obj, true
+		}
+	}
+	return unstructured.Unstructured{}, false
+}
+
+// recreatedResource tells if an owned object replaces the deleted one. Pods get generated names so any new
+// Pod counts, other kinds must come back with the same name.
+func recreatedResource(obj, deleted unstructured.Unstructured, existing map[types.UID]bool) bool {
+	if obj.GetKind() != deleted.GetKind() || existing[obj.GetUID()] || obj.GetDeletionTimestamp() != nil {
+		return false
+	}
+	return obj.GetKind() == "Pod" || obj.GetName() == deleted.GetName()
+}
+
+// disturbResource deletes obj and waits for the operator to recreate it and for the new object to become ready
+func disturbResource(ctx context.Context, options *auditOptions, operand, obj unstructured.Unstructured, owned []unstructured.Unstructured) (report.ResourceRecovery, error) {
+	recovery := report.ResourceRecovery{
+		Kind: obj.GetKind(),
+		Name: obj.GetName(),
+	}
+
+	existing := map[types.UID]bool{}
+	for _, o := range owned {
+		existing[o.GetUID()] = true
+	}
+
+	if err := options.client.DeleteUnstructured(ctx, &obj); err != nil && !apierrors.IsNotFound(err) {
+		return recovery, fmt.Errorf("could not delete %s/%s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+	start := time.Now()
+
+	err := wait.PollImmediateWithContext(ctx, 5*time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, ownedKinds)
+		if err != nil {
+			return false, err
+		}
+
+		for _, o := range owned {
+			if !recreatedResource(o, obj, existing) {
+				continue
+			}
+			ready, err := resourceReady(o)
+			if err != nil {
+				return false, fmt.Errorf("could not read status of %s/%s: %v", o.GetKind(), o.GetName(), err)
+			}
+			if ready {
+				recovery.TimeToRecover = time.Since(start).Round(time.Second)
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return recovery, err
+	}
+	recovery.Recovered = err == nil
+
+	return recovery, nil
+}
+
+// healOperand disturbs one owned resource of each of the selfHealingKinds once the operand is healthy.
+// The deleted objects are appended to disturbed so they can be accounted for during cleanup.
+func healOperand(ctx context.Context, options *auditOptions, operand unstructured.Unstructured, disturbed *[]unstructured.Unstructured) (report.OperandSelfHealing, error) {
+	healing := report.OperandSelfHealing{
+		Kind: operand.GetKind(),
+		Name: operand.GetName(),
+	}
+
+	health, err := waitForOperandHealth(ctx, options, operand)
+	if err != nil {
+		return healing, err
+	}
+	if !health.Healthy {
+		logger.Infow("skipping self healing since operand never became healthy", "kind", operand.GetKind(), "name", operand.GetName())
+		return healing, nil
+	}
+	healing.Healthy = true
+
+	// owned resources are listed again before each deletion since the previous one may have replaced some of them
+	for _, kind := range selfHealingKinds {
+		owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, ownedKinds)
+		if err != nil {
+			return healing, err
+		}
+		obj, found := pickDisturbedResource(owned, kind)
+		if !found {
+			continue
+		}
+
+		*disturbed = append(*disturbed, obj)
+		recovery, err := disturbResource(ctx, options, operand, obj, owned)
+		healing.Resources = append(healing.Resources, recovery)
+		if err != nil {
+			return healing, err
+		}
+	}
+
+	healing.Healed = len(healing.Resources) > 0
+	for _, recovery := range healing.Resources {
+		healing.Healed = healing.Healed && recovery.Recovered
+	}
+
+	return healing, nil
+}
+
+// operandSelfHealing deletes resources owned by each operand created by OperandInstall and checks that the
+// operator restores them
+func operandSelfHealing(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	disturbed := []unstructured.Unstructured{}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking operand self healing for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandSelfHealing") {
+			return nil
+		}
+
+		selfHealing := []report.OperandSelfHealing{}
+		for _, operand := range *options.operands {
+			healing, err := healOperand(ctx, &options, operand, &disturbed)
+			if err != nil {
+				logger.Errorw("could not check operand self healing", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+			}
+			selfHealing = append(selfHealing, healing)
+		}
+
+		return writeReports(&options, "operand_self_healing", report.TemplateData{
+			OperandSelfHealing: selfHealing,
+		}, report.OperandSelfHealingJsonReport, report.OperandSelfHealingTextReport)
+	}, selfHealingCleanup(&options, &disturbed)
+}
+
+// selfHealingCleanup deletes the objects recreated in place of the disturbed ones that were left without an owner,
+// since garbage collection won't remove them along with the operand
+func selfHealingCleanup(options *auditOptions, disturbed *[]unstructured.Unstructured) auditCleanupFn {
+	return func(ctx context.Context) error {
+		for _, obj := range *disturbed {
+			if obj.GetKind() == "Pod" {
+				continue
+			}
+
+			recreated := &unstructured.Unstructured{}
+			recreated.SetGroupVersionKind(obj.GroupVersionKind())
+			err := options.client.GetUnstructured(ctx, obj.GetNamespace(), obj.GetName(), recreated)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("could not get %s: %v", strings.Join([]string{obj.GetKind(), obj.GetName()}, "/"), err)
+			}
+			if len(recreated.GetOwnerReferences()) > 0 {
+				continue
+			}
+
+			logger.Debugw("deleting orphaned resource recreated during self healing", "kind", obj.GetKind(), "name", obj.GetName())
+			if err := options.client.DeleteUnstructured(ctx, recreated); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
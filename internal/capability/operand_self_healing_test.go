@@ -0,0 +1,87 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Operand self healing", func() {
+	Context("pickDisturbedResource", func() {
+		It("should skip objects that are being deleted", func() {
+			deleting := newOwnedObject("Pod", "deleting", "deleting-uid", "operand-uid")
+			now := metav1.Now()
+			deleting.SetDeletionTimestamp(&now)
+			owned := []unstructured.Unstructured{
+				newOwnedObject("Deployment", "test", "deployment-uid", "operand-uid"),
+				deleting,
+				newOwnedObject("Pod", "running", "running-uid", "operand-uid"),
+			}
+
+			obj, found := pickDisturbedResource(owned, "Pod")
+			Expect(found).To(BeTrue())
+			Expect(obj.GetName()).To(Equal("running"))
+
+			_, found = pickDisturbedResource(owned, "Service")
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("recreatedResource", func() {
+		existing := map[types.UID]bool{"deployment-uid": true, "pod-uid": true}
+
+		It("should require the same name for named kinds", func() {
+			deleted := newOwnedObject("Deployment", "test", "deployment-uid", "operand-uid")
+			Expect(recreatedResource(newOwnedObject("Deployment", "test", "deployment-uid", "operand-uid"), deleted, existing)).To(BeFalse())
+			Expect(recreatedResource(newOwnedObject("Deployment", "other", "other-uid", "operand-uid"), deleted, existing)).To(BeFalse())
+			Expect(recreatedResource(newOwnedObject("Deployment", "test", "new-uid", "operand-uid"), deleted, existing)).To(BeTrue())
+		})
+		It("should accept any new Pod", func() {
+			deleted := newOwnedObject("Pod", "test-abc", "pod-uid", "operand-uid")
+			Expect(recreatedResource(newOwnedObject("Pod", "test-def", "new-uid", "operand-uid"), deleted, existing)).To(BeTrue())
+			Expect(recreatedResource(newOwnedObject("Service", "test-def", "new-uid", "operand-uid"), deleted, existing)).To(BeFalse())
+		})
+	})
+
+	Context("selfHealingCleanup", func() {
+		It("should only delete recreated resources left without an owner", func() {
+			orphan := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "testns"}}
+			owned := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:            "owned",
+				Namespace:       "testns",
+				OwnerReferences: []metav1.OwnerReference{{UID: "operand-uid", Name: "owner", Kind: "Owner", APIVersion: "v1"}},
+			}}
+			client := operator.NewFakeOpClient(orphan, owned)
+
+			disturbed := []unstructured.Unstructured{}
+			for _, obj := range []struct{ kind, name string }{{"ConfigMap", "orphan"}, {"Service", "owned"}, {"Deployment", "gone"}} {
+				u := unstructured.Unstructured{Object: map[string]interface{}{}}
+				u.SetAPIVersion("v1")
+				if obj.kind == "Deployment" {
+					u.SetAPIVersion("apps/v1")
+				}
+				u.SetKind(obj.kind)
+				u.SetName(obj.name)
+				u.SetNamespace("testns")
+				disturbed = append(disturbed, u)
+			}
+
+			Expect(selfHealingCleanup(&auditOptions{client: client}, &disturbed)(context.TODO())).To(Succeed())
+
+			configMap := &unstructured.Unstructured{}
+			configMap.SetGroupVersionKind(disturbed[0].GroupVersionKind())
+			Expect(apierrors.IsNotFound(client.GetUnstructured(context.TODO(), "testns", "orphan", configMap))).To(BeTrue())
+
+			service := &unstructured.Unstructured{}
+			service.SetGroupVersionKind(disturbed[1].GroupVersionKind())
+			Expect(client.GetUnstructured(context.TODO(), "testns", "owned", service)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// readinessConditionTypes are the condition types accepted as an operand readiness signal
+var readinessConditionTypes = []string{"Ready", "Available"}
+
+// readOperandStatus reads the conditions and observed generation from an operand's status block.
+// The observed generation is taken from status.observedGeneration or, when the operator only
+// sets it per condition, from the readiness condition itself.
+func readOperandStatus(obj unstructured.Unstructured) report.OperandStatus {
+	status := report.OperandStatus{
+		Kind:       obj.GetKind(),
+		Name:       obj.GetName(),
+		Generation: obj.GetGeneration(),
+	}
+
+	observedGeneration, hasObservedGeneration, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+
+	var ready bool
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		conditionStatus, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+		status.Conditions = append(status.Conditions, report.Condition{
+			Type:    conditionType,
+			Status:  conditionStatus,
+			Reason:  reason,
+			Message: message,
+		})
+
+		for _, readinessType := range readinessConditionTypes {
+			if !strings.EqualFold(conditionType, readinessType) {
+				continue
+			}
+			ready = ready || strings.EqualFold(conditionStatus, "true")
+			if !hasObservedGeneration {
+				observedGeneration, hasObservedGeneration, _ = unstructured.NestedInt64(condition, "observedGeneration")
+			}
+		}
+	}
+	status.ObservedGeneration = observedGeneration
+
+	status.Passed = ready && hasObservedGeneration && observedGeneration == status.Generation
+	return status
+}
+
+// operandStatus checks that the operator conveys readiness of each operand through the CR status block
+func operandStatus(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking operand status for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperandStatus") {
+			return nil
+		}
+
+		operandStatus := []report.OperandStatus{}
+		for _, operand := range *options.operands {
+			obj := operand.DeepCopy()
+			var status report.OperandStatus
+
+			err := wait.PollImmediateWithContext(ctx, 5*time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+				if err := options.client.GetUnstructured(ctx, obj.GetNamespace(), obj.GetName(), obj); err != nil {
+					return false, err
+				}
+				status = readOperandStatus(*obj)
+				return status.Passed, nil
+			})
+			if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+				logger.Errorw("could not get operand status", "error", err, "kind", operand.GetKind(), "name", operand.GetName())
+				status = report.OperandStatus{Kind: operand.GetKind(), Name: operand.GetName()}
+			}
+			operandStatus = append(operandStatus, status)
+		}
+
+		return writeReports(&options, "operand_status", report.TemplateData{
+			OperandStatus: operandStatus,
+		}, report.OperandStatusJsonReport, report.OperandStatusTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,60 @@
+package capability
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Operand status", func() {
+	var obj unstructured.Unstructured
+
+	BeforeEach(func() {
+		obj = unstructured.Unstructured{Object: map[string]interface{}{}}
+		obj.SetKind("testkind")
+		obj.SetName("testname")
+		obj.SetGeneration(2)
+	})
+
+	When("the operand is ready at the current generation", func() {
+		It("should pass", func() {
+			Expect(unstructured.SetNestedField(obj.Object, int64(2), "status", "observedGeneration")).To(Succeed())
+			Expect(unstructured.SetNestedSlice(obj.Object, []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+			}, "status", "conditions")).To(Succeed())
+
+			status := readOperandStatus(obj)
+			Expect(status.Passed).To(BeTrue())
+			Expect(status.Conditions).To(HaveLen(1))
+		})
+	})
+	When("the observed generation is only set on the condition", func() {
+		It("should pass", func() {
+			Expect(unstructured.SetNestedSlice(obj.Object, []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True", "observedGeneration": int64(2)},
+			}, "status", "conditions")).To(Succeed())
+
+			Expect(readOperandStatus(obj).Passed).To(BeTrue())
+		})
+	})
+	When("the observed generation is behind", func() {
+		It("should fail", func() {
+			Expect(unstructured.SetNestedField(obj.Object, int64(1), "status", "observedGeneration")).To(Succeed())
+			Expect(unstructured.SetNestedSlice(obj.Object, []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+			}, "status", "conditions")).To(Succeed())
+
+			Expect(readOperandStatus(obj).Passed).To(BeFalse())
+		})
+	})
+	When("there is no readiness condition", func() {
+		It("should fail", func() {
+			Expect(unstructured.SetNestedField(obj.Object, int64(2), "status", "observedGeneration")).To(Succeed())
+			Expect(unstructured.SetNestedSlice(obj.Object, []interface{}{
+				map[string]interface{}{"type": "Progressing", "status": "True"},
+			}, "status", "conditions")).To(Succeed())
+
+			Expect(readOperandStatus(obj).Passed).To(BeFalse())
+		})
+	})
+})
@@ -6,6 +6,9 @@ import (
 
 	"github.com/opdev/opcap/internal/logger"
 	"github.com/opdev/opcap/internal/operator"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 func operatorCleanup(ctx context.Context, opts ...auditOption) auditCleanupFn {
@@ -20,40 +23,49 @@ func operatorCleanup(ctx context.Context, opts ...auditOption) auditCleanupFn {
 	}
 
 	return func(ctx context.Context) error {
-		// delete subscription
-		if err := options.client.DeleteSubscription(ctx, options.subscription.Name, options.namespace); err != nil {
-			logger.Debugf("Error while deleting Subscription: %w", err)
+		// errors are collected instead of returned right away so that a failed delete doesn't keep
+		// the remaining objects around. Objects that are already gone are not an error.
+		errs := []error{}
+		collect := func(err error, msg string, keysAndValues ...interface{}) {
+			if err == nil || apierrors.IsNotFound(err) {
+				return
+			}
+			logger.Errorw(msg, append([]interface{}{"error", err}, keysAndValues...)...)
+			errs = append(errs, fmt.Errorf("%s: %v", msg, err))
 		}
 
+		// delete subscription
+		collect(options.client.DeleteSubscription(ctx, options.subscription.Name, options.namespace),
+			"could not delete Subscription", "subscription", options.subscription.Name, "namespace", options.namespace)
+
 		// get csv using csvWatcher
 		csv, err := options.client.GetCompletedCsvWithTimeout(ctx, options.namespace, options.csvWaitTime)
-		if err != operator.TimeoutError && err != nil {
-			logger.Debugf("Error while deleting CSV: %w", err)
+		if err != operator.TimeoutError {
+			collect(err, "could not get ClusterServiceVersion", "namespace", options.namespace)
 		}
 
 		if csv != nil {
 			// delete cluster service version
-			if err := options.client.DeleteCSV(ctx, csv.ObjectMeta.Name, options.namespace); err != nil {
-				logger.Debugf("Error while deleting ClusterServiceVersion: %w", err)
-			}
+			collect(options.client.DeleteCSV(ctx, csv.ObjectMeta.Name, options.namespace),
+				"could not delete ClusterServiceVersion", "csv", csv.ObjectMeta.Name, "namespace", options.namespace)
 		}
 
 		// delete operator group
-		if err := options.client.DeleteOperatorGroup(ctx, options.operatorGroupData.Name, options.namespace); err != nil {
-			logger.Debugf("Error while deleting OperatorGroup: %w", err)
-		}
+		collect(options.client.DeleteOperatorGroup(ctx, options.operatorGroupData.Name, options.namespace),
+			"could not delete OperatorGroup", "operatorgroup", options.operatorGroupData.Name, "namespace", options.namespace)
 
 		// delete target namespaces
 		for _, ns := range options.operatorGroupData.TargetNamespaces {
-			if err := options.client.DeleteNamespace(ctx, ns); err != nil {
-				logger.Debugf("Error deleting target namespace %s", ns)
+			// OwnNamespace installs target the operator's own namespace, which is deleted below
+			if ns == options.namespace {
+				continue
 			}
+			collect(options.client.DeleteNamespace(ctx, ns), "could not delete target namespace", "namespace", ns)
 		}
 
 		// delete operator's own namespace
-		if err := options.client.DeleteNamespace(ctx, options.namespace); err != nil {
-			logger.Debugf("Error deleting operator's own namespace %s", options.namespace)
-		}
-		return nil
+		collect(options.client.DeleteNamespace(ctx, options.namespace), "could not delete operator's own namespace", "namespace", options.namespace)
+
+		return utilerrors.NewAggregate(errs)
 	}
 }
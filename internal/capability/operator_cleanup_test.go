@@ -0,0 +1,36 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Operator cleanup", func() {
+	var client operator.Client
+	var opts []auditOption
+
+	BeforeEach(func() {
+		client = operator.NewFakeOpClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns"}})
+		opts = []auditOption{
+			withClient(client),
+			withNamespace("testns"),
+			withSubscription(&operator.SubscriptionData{Name: "testsub"}),
+			withOperatorGroupData(&operator.OperatorGroupData{Name: "testgroup", TargetNamespaces: []string{"testns"}}),
+			withTimeout(0),
+		}
+	})
+
+	When("objects are already gone", func() {
+		It("should delete the namespace and succeed", func() {
+			Expect(operatorCleanup(context.TODO(), opts...)(context.TODO())).To(Succeed())
+
+			Expect(apierrors.IsNotFound(client.DeleteNamespace(context.TODO(), "testns"))).To(BeTrue())
+		})
+	})
+})
@@ -5,12 +5,128 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/opdev/opcap/internal/logger"
 	"github.com/opdev/opcap/internal/operator"
 	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
+// restrictedPodSecurityLabels enforce the restricted Pod Security Admission profile on a namespace. The label
+// sync is turned off so OpenShift doesn't relax the enforced profile based on the SCCs available to the namespace.
+var restrictedPodSecurityLabels = map[string]string{
+	"pod-security.kubernetes.io/enforce":             "restricted",
+	"security.openshift.io/scc.podSecurityLabelSync": "false",
+}
+
+// enforceRestrictedPodSecurity labels the operator's own and target namespaces with restrictedPodSecurityLabels
+func enforceRestrictedPodSecurity(ctx context.Context, options *auditOptions) error {
+	for _, ns := range auditNamespaces(options) {
+		if err := options.client.LabelNamespace(ctx, ns, restrictedPodSecurityLabels); err != nil {
+			return fmt.Errorf("could not enforce restricted pod security: %v", err)
+		}
+	}
+	return nil
+}
+
+// scopedServiceAccountName is the ServiceAccount OLM installs operators with in least privilege mode
+const scopedServiceAccountName = "opcap-installer"
+
+// namespaceScopedRules grant full access to namespaced resources, and nothing cluster wide since they are bound
+// through Roles
+var namespaceScopedRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{"*"},
+		Resources: []string{"*"},
+		Verbs:     []string{"*"},
+	},
+}
+
+// scopeOperatorGroup scopes the OperatorGroup to a ServiceAccount with namespace-only permissions in the operator's
+// own and target namespaces, so that OLM installs the operator without cluster-admin privileges
+func scopeOperatorGroup(ctx context.Context, options *auditOptions) error {
+	options.operatorGroupData.ServiceAccountName = scopedServiceAccountName
+	options.operatorGroupData.ServiceAccountRules = namespaceScopedRules
+	if err := options.client.CreateScopedServiceAccount(ctx, *options.operatorGroupData, options.namespace); err != nil {
+		return fmt.Errorf("could not scope operator group: %v", err)
+	}
+	return nil
+}
+
+// installPlanFailures returns the messages of the failed conditions of the InstallPlans in the operator's namespace.
+// In least privilege mode these are the permissions OLM was missing to install the operator.
+func installPlanFailures(ctx context.Context, options *auditOptions) ([]string, error) {
+	installPlans, err := options.client.ListInstallPlans(ctx, options.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := []string{}
+	for _, installPlan := range installPlans.Items {
+		for _, condition := range installPlan.Status.Conditions {
+			if condition.Status != corev1.ConditionFalse || condition.Message == "" {
+				continue
+			}
+			failures = append(failures, strings.Replace(condition.Message, "\"", "", -1))
+		}
+	}
+	return failures, nil
+}
+
+// recordInstalledCSV shares csv with the audits that need it once the operator has been cleaned up
+func recordInstalledCSV(options *auditOptions, csv *operatorv1alpha1.ClusterServiceVersion) {
+	if csv == nil || csv.Name == "" || options.installedCSV == nil {
+		return
+	}
+	*options.installedCSV = *csv
+}
+
+// prepareInstall creates the operator's own and target namespaces and the OperatorGroup the operator is subscribed
+// with. The OperatorGroup is scoped to a ServiceAccount and the restricted pod security profile is enforced when
+// requested. It is shared by the OperatorInstall and OperatorUpgrade audits.
+func prepareInstall(ctx context.Context, options *auditOptions) error {
+	// create operator's own namespace
+	if _, err := options.client.CreateNamespace(ctx, options.namespace); err != nil {
+		return err
+	}
+
+	// create remaining target namespaces watched by the operator
+	for _, ns := range options.operatorGroupData.TargetNamespaces {
+		if ns == options.namespace {
+			continue
+		}
+		if _, err := options.client.CreateNamespace(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	// scope the operator group to a service account before OLM installs anything
+	if options.leastPrivilege {
+		if err := scopeOperatorGroup(ctx, options); err != nil {
+			return err
+		}
+	}
+
+	// create operator group for operator package/channel
+	if _, err := options.client.CreateOperatorGroup(ctx, *options.operatorGroupData, options.namespace); err != nil {
+		return err
+	}
+
+	// enforce the restricted pod security profile before OLM deploys anything in the namespaces
+	if options.restrictedPodSecurity {
+		if err := enforceRestrictedPodSecurity(ctx, options); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func operatorInstall(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
 	var options auditOptions
 	for _, opt := range opts {
@@ -28,25 +144,13 @@ func operatorInstall(ctx context.Context, opts ...auditOption) (auditFn, auditCl
 	return func(ctx context.Context) error {
 		logger.Debugw("installing package", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
 
-		// create operator's own namespace
-		if _, err := options.client.CreateNamespace(ctx, options.namespace); err != nil {
+		if err := prepareInstall(ctx, &options); err != nil {
 			return err
 		}
 
-		// create remaining target namespaces watched by the operator
-		for _, ns := range options.operatorGroupData.TargetNamespaces {
-			if ns != options.namespace {
-				options.client.CreateNamespace(ctx, ns)
-			}
-		}
-
-		// create operator group for operator package/channel
-		options.client.CreateOperatorGroup(ctx, *options.operatorGroupData, options.namespace)
-
 		// create subscription for operator package/channel
 		if _, err := options.client.CreateSubscription(ctx, *options.subscription, options.namespace); err != nil {
-			logger.Debugf("Error creating subscriptions: %w", err)
-			return err
+			return fmt.Errorf("could not create subscription: %v", err)
 		}
 
 		// Get a Succeeded or Failed CSV with one minute timeout
@@ -65,6 +169,16 @@ func operatorInstall(ctx context.Context, opts ...auditOption) (auditFn, auditCl
 			}
 		}
 		options.csv = resultCSV
+		recordInstalledCSV(&options, resultCSV)
+
+		leastPrivilege := report.LeastPrivilege{}
+		if options.leastPrivilege {
+			leastPrivilege.ServiceAccount = options.operatorGroupData.ServiceAccountName
+			leastPrivilege.PermissionFailures, err = installPlanFailures(ctx, &options)
+			if err != nil {
+				logger.Errorw("could not read installplan conditions", "error", err, "namespace", options.namespace)
+			}
+		}
 
 		file, err := options.fs.OpenFile("operator_install_report.json", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
 		if err != nil {
@@ -73,20 +187,24 @@ func operatorInstall(ctx context.Context, opts ...auditOption) (auditFn, auditCl
 		defer file.Close()
 
 		err = report.OperatorInstallJsonReport(file, report.TemplateData{
-			OcpVersion:   options.ocpVersion,
-			Subscription: *options.subscription,
-			Csv:          options.csv,
-			CsvTimeout:   options.csvTimeout,
+			OcpVersion:            options.ocpVersion,
+			Subscription:          *options.subscription,
+			Csv:                   options.csv,
+			CsvTimeout:            options.csvTimeout,
+			RestrictedPodSecurity: options.restrictedPodSecurity,
+			LeastPrivilege:        leastPrivilege,
 		})
 		if err != nil {
 			return fmt.Errorf("could not generate operator install JSON report: %v", err)
 		}
 
 		err = report.OperatorInstallTextReport(options.reportWriter, report.TemplateData{
-			OcpVersion:   options.ocpVersion,
-			Subscription: *options.subscription,
-			Csv:          options.csv,
-			CsvTimeout:   options.csvTimeout,
+			OcpVersion:            options.ocpVersion,
+			Subscription:          *options.subscription,
+			Csv:                   options.csv,
+			CsvTimeout:            options.csvTimeout,
+			RestrictedPodSecurity: options.restrictedPodSecurity,
+			LeastPrivilege:        leastPrivilege,
 		})
 		if err != nil {
 			return fmt.Errorf("could not generate operator install text report: %v", err)
@@ -0,0 +1,118 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	operatorv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Operator install", func() {
+	Context("prepareInstall", func() {
+		var options *auditOptions
+
+		BeforeEach(func() {
+			options = &auditOptions{
+				client:            operator.NewFakeOpClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns-targetns1"}}),
+				namespace:         "testns",
+				operatorGroupData: &operator.OperatorGroupData{Name: "testog", TargetNamespaces: []string{"testns-targetns1", "testns-targetns2"}},
+			}
+		})
+		It("should create the namespaces and the operator group", func() {
+			Expect(prepareInstall(context.TODO(), options)).To(Succeed())
+
+			for _, name := range []string{"testns", "testns-targetns1", "testns-targetns2"} {
+				ns := &unstructured.Unstructured{}
+				ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+				Expect(options.client.GetUnstructured(context.TODO(), "", name, ns)).To(Succeed())
+			}
+			operatorGroup := &unstructured.Unstructured{}
+			operatorGroup.SetGroupVersionKind(operatorv1.SchemeGroupVersion.WithKind("OperatorGroup"))
+			Expect(options.client.GetUnstructured(context.TODO(), "testns", "testog", operatorGroup)).To(Succeed())
+		})
+		It("should return operator group creation errors", func() {
+			_, err := options.client.CreateOperatorGroup(context.TODO(), *options.operatorGroupData, "testns")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(prepareInstall(context.TODO(), options)).To(MatchError(ContainSubstring("could not create operatorgroup")))
+		})
+		It("should return namespace creation errors", func() {
+			_, err := options.client.CreateNamespace(context.TODO(), "testns")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(prepareInstall(context.TODO(), options)).To(MatchError(ContainSubstring("could not create namespace")))
+		})
+	})
+	Context("enforceRestrictedPodSecurity", func() {
+		It("should label the operator's own and target namespaces", func() {
+			client := operator.NewFakeOpClient(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns-targetns1"}},
+			)
+			options := &auditOptions{
+				client:            client,
+				namespace:         "testns",
+				operatorGroupData: &operator.OperatorGroupData{TargetNamespaces: []string{"testns-targetns1"}},
+			}
+
+			Expect(enforceRestrictedPodSecurity(context.TODO(), options)).To(Succeed())
+
+			for _, name := range []string{"testns", "testns-targetns1"} {
+				ns := &unstructured.Unstructured{}
+				ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+				Expect(client.GetUnstructured(context.TODO(), "", name, ns)).To(Succeed())
+				Expect(ns.GetLabels()).To(HaveKeyWithValue("pod-security.kubernetes.io/enforce", "restricted"))
+			}
+		})
+	})
+	Context("scopeOperatorGroup", func() {
+		It("should scope the operator group to a service account with namespace-only permissions", func() {
+			client := operator.NewFakeOpClient()
+			options := &auditOptions{
+				client:            client,
+				namespace:         "testns",
+				operatorGroupData: &operator.OperatorGroupData{Name: "testog", TargetNamespaces: []string{"testns-targetns1"}},
+			}
+
+			Expect(scopeOperatorGroup(context.TODO(), options)).To(Succeed())
+			Expect(options.operatorGroupData.ServiceAccountName).To(Equal(scopedServiceAccountName))
+			Expect(options.operatorGroupData.ServiceAccountRules).To(Equal(namespaceScopedRules))
+
+			for _, ns := range []string{"testns", "testns-targetns1"} {
+				role := &unstructured.Unstructured{}
+				role.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind("Role"))
+				Expect(client.GetUnstructured(context.TODO(), ns, scopedServiceAccountName, role)).To(Succeed())
+			}
+		})
+	})
+	Context("installPlanFailures", func() {
+		It("should return the failed installplan conditions", func() {
+			installPlan := &operatorv1alpha1.InstallPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "install-abcde", Namespace: "testns"},
+				Status: operatorv1alpha1.InstallPlanStatus{
+					Conditions: []operatorv1alpha1.InstallPlanCondition{
+						{Type: operatorv1alpha1.InstallPlanResolved, Status: corev1.ConditionTrue},
+						{
+							Type:    operatorv1alpha1.InstallPlanInstalled,
+							Status:  corev1.ConditionFalse,
+							Reason:  operatorv1alpha1.InstallPlanReasonComponentFailed,
+							Message: `clusterroles.rbac.authorization.k8s.io is forbidden: User "system:serviceaccount:testns:opcap-installer" cannot create resource "clusterroles"`,
+						},
+					},
+				},
+			}
+			options := &auditOptions{client: operator.NewFakeOpClient(installPlan), namespace: "testns"}
+
+			failures, err := installPlanFailures(context.TODO(), options)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(failures).To(ConsistOf(`clusterroles.rbac.authorization.k8s.io is forbidden: User system:serviceaccount:testns:opcap-installer cannot create resource clusterroles`))
+		})
+	})
+})
@@ -0,0 +1,310 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// restartSettlePeriod is the minimum time the restarted operator is given to reconcile the operands before their
+// owned resources are compared
+const restartSettlePeriod = 30 * time.Second
+
+// snapshotOwned lists the resources owned by operand. Pods and ReplicaSets are left out since they are replaced
+// by their controllers regardless of the operator.
+func snapshotOwned(ctx context.Context, options *auditOptions, operand unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	owned, err := listOwnedResources(ctx, options, operand.GetNamespace(), operand, ownedKinds)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := []unstructured.Unstructured{}
+	for _, obj := range owned {
+		if obj.GetKind() == "Pod" || obj.GetKind() == "ReplicaSet" || obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+		snapshot = append(snapshot, obj)
+	}
+	return snapshot, nil
+}
+
+// compareOwned fills restart with the owned resources changed across the operator restart, along with the ones
+// recreated with a new UID and the ones created again from the same generateName while an earlier one still exists
+func compareOwned(restart *report.OperandRestart, before, after []unstructured.Unstructured) {
+	restart.Changed = changedResources(before, after)
+
+	previous := map[string]unstructured.Unstructured{}
+	existing := map[types.UID]bool{}
+	for _, obj := range before {
+		previous[strings.Join([]string{obj.GetKind(), obj.GetName()}, "/")] = obj
+		existing[obj.GetUID()] = true
+	}
+
+	generated := map[string]int{}
+	for _, obj := range after {
+		if obj.GetGenerateName() != "" {
+			generated[strings.Join([]string{obj.GetKind(), obj.GetGenerateName()}, "/")]++
+		}
+	}
+
+	for _, obj := range after {
+		key := strings.Join([]string{obj.GetKind(), obj.GetName()}, "/")
+		if old, ok := previous[key]; ok && old.GetUID() != obj.GetUID() {
+			restart.Recreated = append(restart.Recreated, fmt.Sprintf("%s (%s -> %s)", key, old.GetUID(), obj.GetUID()))
+		}
+		if !existing[obj.GetUID()] && generated[strings.Join([]string{obj.GetKind(), obj.GetGenerateName()}, "/")] > 1 {
+			restart.Duplicated = append(restart.Duplicated, key)
+		}
+	}
+	sort.Strings(restart.Recreated)
+	sort.Strings(restart.Duplicated)
+}
+
+// findOrphans fills restart with the resources owned by the operand before the restart that still exist, under
+// the same UID, without being owned by it anymore
+func findOrphans(ctx context.Context, options *auditOptions, restart *report.OperandRestart, before, after []unstructured.Unstructured) error {
+	owned := map[types.UID]bool{}
+	for _, obj := range after {
+		owned[obj.GetUID()] = true
+	}
+
+	for _, old := range before {
+		if owned[old.GetUID()] {
+			continue
+		}
+		key := strings.Join([]string{old.GetKind(), old.GetName()}, "/")
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(old.GroupVersionKind())
+		err := options.client.GetUnstructured(ctx, old.GetNamespace(), old.GetName(), obj)
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return fmt.Errorf("could not get %s: %v", key, err)
+		case obj.GetUID() == old.GetUID() && obj.GetDeletionTimestamp() == nil:
+			restart.Orphaned = append(restart.Orphaned, key)
+		}
+	}
+	sort.Strings(restart.Orphaned)
+
+	return nil
+}
+
+// deploymentPods lists the pods selected by the given operator deployments
+func deploymentPods(ctx context.Context, clientset kubernetes.Interface, namespace string, deployments []string) ([]corev1.Pod, error) {
+	pods := []corev1.Pod{}
+	for _, name := range deployments {
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("could not get deployment %s: %v", name, err)
+		}
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("could not read selector of deployment %s: %v", name, err)
+		}
+		list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, fmt.Errorf("could not list pods of deployment %s: %v", name, err)
+		}
+		pods = append(pods, list.Items...)
+	}
+	return pods, nil
+}
+
+// deploymentPodsReady tells if every operator deployment has as many ready pods as replicas, not counting deleted pods
+func deploymentPodsReady(ctx context.Context, clientset kubernetes.Interface, namespace string, deployments []string, deleted map[types.UID]bool) (bool, error) {
+	for _, name := range deployments {
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("could not get deployment %s: %v", name, err)
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+
+		pods, err := deploymentPods(ctx, clientset, namespace, []string{name})
+		if err != nil {
+			return false, err
+		}
+		ready := int32(0)
+		for _, pod := range pods {
+			if deleted[pod.UID] || pod.DeletionTimestamp != nil {
+				continue
+			}
+			for _, condition := range pod.Status.Conditions {
+				if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+					ready++
+				}
+			}
+		}
+		if ready < replicas {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// restartOperator deletes the pods of the operator deployments and waits for them to be replaced by ready ones
+func restartOperator(ctx context.Context, options *auditOptions, clientset kubernetes.Interface, deployments []string, restart *report.OperatorRestart) error {
+	pods, err := deploymentPods(ctx, clientset, options.namespace, deployments)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("no pods found for operator deployments %s", strings.Join(deployments, ", "))
+	}
+
+	deleted := map[types.UID]bool{}
+	for _, pod := range pods {
+		if err := clientset.CoreV1().Pods(options.namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("could not delete operator pod %s: %v", pod.Name, err)
+		}
+		deleted[pod.UID] = true
+		restart.DeletedPods = append(restart.DeletedPods, pod.Name)
+	}
+	start := time.Now()
+
+	err = wait.PollImmediateWithContext(ctx, 5*time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+		return deploymentPodsReady(ctx, clientset, options.namespace, deployments, deleted)
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return err
+	}
+	restart.Restarted = err == nil
+	if restart.Restarted {
+		restart.RestartTime = time.Since(start).Round(time.Second)
+	}
+
+	return nil
+}
+
+// operatorRestartPassed tells if the operator came back and every operand converged to a healthy state without
+// recreating, duplicating or orphaning owned resources. Other changes are expected since the operands were still
+// being reconciled when the operator went away.
+func operatorRestartPassed(restart report.OperatorRestart) bool {
+	if !restart.Restarted || len(restart.Operands) == 0 {
+		return false
+	}
+	for _, operand := range restart.Operands {
+		if operand.Error != "" || !operand.Healthy || len(operand.Recreated) > 0 || len(operand.Duplicated) > 0 ||
+			len(operand.Orphaned) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// operatorRestart deletes the operator pods right after OperandInstall, while the operands are still being
+// reconciled, waits for the operator to come back and checks that the operands converge to a healthy state
+// without recreated, duplicated or orphaned owned resources
+func operatorRestart(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking operator restart for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if !requireOperands(&options, "OperatorRestart") {
+			return nil
+		}
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+		deployments := []string{}
+		for _, deployment := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+			deployments = append(deployments, deployment.Name)
+		}
+		if len(deployments) == 0 {
+			logger.Infow("exiting OperatorRestart since the CSV has no deployments")
+			return nil
+		}
+
+		clientset, err := k8sClientset()
+		if err != nil {
+			return err
+		}
+
+		restart := report.OperatorRestart{}
+		// snapshots holds the owned resources of each operand as the operator left them, nil when they couldn't be
+		// listed
+		snapshots := [][]unstructured.Unstructured{}
+		for _, operand := range *options.operands {
+			operandRestart := report.OperandRestart{
+				Kind: operand.GetKind(),
+				Name: operand.GetName(),
+			}
+			snapshot, err := snapshotOwned(ctx, &options, operand)
+			if err != nil {
+				operandRestart.Error = err.Error()
+			}
+			restart.Operands = append(restart.Operands, operandRestart)
+			snapshots = append(snapshots, snapshot)
+		}
+
+		if err := restartOperator(ctx, &options, clientset, deployments, &restart); err != nil {
+			return fmt.Errorf("could not restart operator: %v", err)
+		}
+		start := time.Now()
+
+		for i, operand := range *options.operands {
+			if snapshots[i] == nil {
+				continue
+			}
+			health, err := waitForOperandHealth(ctx, &options, operand)
+			switch {
+			case err != nil:
+				restart.Operands[i].Error = err.Error()
+			case health.NoWorkloads:
+				restart.Operands[i].Error = "operand owns no workloads"
+			default:
+				restart.Operands[i].Healthy = health.Healthy
+			}
+		}
+
+		// let the restarted operator go through its reconciliation before comparing the owned resources
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(restartSettlePeriod - time.Since(start)):
+		}
+
+		for i, operand := range *options.operands {
+			if snapshots[i] == nil || restart.Operands[i].Error != "" {
+				continue
+			}
+			after, err := snapshotOwned(ctx, &options, operand)
+			if err != nil {
+				restart.Operands[i].Error = err.Error()
+				continue
+			}
+			compareOwned(&restart.Operands[i], snapshots[i], after)
+			if err := findOrphans(ctx, &options, &restart.Operands[i], snapshots[i], after); err != nil {
+				restart.Operands[i].Error = err.Error()
+			}
+		}
+		restart.Passed = operatorRestartPassed(restart)
+
+		return writeReports(&options, "operator_restart", report.TemplateData{
+			OperatorRestart: restart,
+		}, report.OperatorRestartJsonReport, report.OperatorRestartTextReport)
+	}, noCleanup
+}
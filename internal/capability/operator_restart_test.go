@@ -0,0 +1,152 @@
+package capability
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func operatorPod(name, uid string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name, UID: types.UID(uid), Labels: map[string]string{"app": "operator"}},
+		Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+	}
+}
+
+var _ = Describe("Operator restart", func() {
+	When("comparing the owned resources before and after the restart", func() {
+		It("should report changed, recreated and duplicated resources", func() {
+			scaled := newOwnedObject("Deployment", "scaled", "2", "operand-uid")
+			scaled.SetGeneration(1)
+			worker := newOwnedObject("Job", "worker-abcde", "4", "operand-uid")
+			worker.SetGenerateName("worker-")
+			before := []unstructured.Unstructured{
+				newOwnedObject("Deployment", "kept", "1", "operand-uid"),
+				scaled,
+				newOwnedObject("ConfigMap", "config", "3", "operand-uid"),
+				worker,
+			}
+			scaledAgain := scaled.DeepCopy()
+			scaledAgain.SetGeneration(2)
+			duplicate := newOwnedObject("Job", "worker-fghij", "6", "operand-uid")
+			duplicate.SetGenerateName("worker-")
+			after := []unstructured.Unstructured{
+				newOwnedObject("Deployment", "kept", "1", "operand-uid"),
+				*scaledAgain,
+				newOwnedObject("ConfigMap", "config", "5", "operand-uid"),
+				worker,
+				duplicate,
+				newOwnedObject("Service", "added", "7", "operand-uid"),
+			}
+
+			restart := report.OperandRestart{}
+			compareOwned(&restart, before, after)
+			Expect(restart.Changed).To(ConsistOf(
+				"Deployment/scaled (updated)",
+				"ConfigMap/config (created)",
+				"ConfigMap/config (deleted)",
+				"Job/worker-fghij (created)",
+				"Service/added (created)",
+			))
+			Expect(restart.Recreated).To(Equal([]string{"ConfigMap/config (3 -> 5)"}))
+			Expect(restart.Duplicated).To(Equal([]string{"Job/worker-fghij"}))
+		})
+		It("should tell orphaned resources from removed ones", func() {
+			orphan := newOwnedObject("ConfigMap", "orphan", "1", "")
+			orphan.SetAPIVersion("v1")
+			orphan.SetNamespace("testns")
+			gone := newOwnedObject("ConfigMap", "gone", "2", "operand-uid")
+			gone.SetAPIVersion("v1")
+			gone.SetNamespace("testns")
+			options := &auditOptions{client: operator.NewFakeOpClient()}
+			Expect(options.client.CreateUnstructured(context.TODO(), &orphan)).To(Succeed())
+			owned := orphan.DeepCopy()
+			owned.SetOwnerReferences(gone.GetOwnerReferences())
+
+			restart := report.OperandRestart{}
+			Expect(findOrphans(context.TODO(), options, &restart, []unstructured.Unstructured{*owned, gone}, nil)).To(Succeed())
+			Expect(restart.Orphaned).To(Equal([]string{"ConfigMap/orphan"}))
+
+			restart = report.OperandRestart{}
+			Expect(findOrphans(context.TODO(), options, &restart, []unstructured.Unstructured{*owned, gone}, []unstructured.Unstructured{orphan})).To(Succeed())
+			Expect(restart.Orphaned).To(BeEmpty())
+		})
+	})
+	When("restarting the operator", func() {
+		var clientset *fake.Clientset
+
+		BeforeEach(func() {
+			clientset = fake.NewSimpleClientset(
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "operator"},
+					Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "operator"}}},
+				},
+				operatorPod("operator-abcde", "1", true),
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "operand", Labels: map[string]string{"app": "operand"}}},
+			)
+		})
+		It("should only find the pods of the operator deployments", func() {
+			pods, err := deploymentPods(context.TODO(), clientset, "testns", []string{"operator"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pods).To(HaveLen(1))
+			Expect(pods[0].Name).To(Equal("operator-abcde"))
+
+			_, err = deploymentPods(context.TODO(), clientset, "testns", []string{"missing"})
+			Expect(err).To(HaveOccurred())
+		})
+		It("should wait for ready pods replacing the deleted ones", func() {
+			deleted := map[types.UID]bool{"1": true}
+			restarted, err := deploymentPodsReady(context.TODO(), clientset, "testns", []string{"operator"}, deleted)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restarted).To(BeFalse())
+
+			_, err = clientset.CoreV1().Pods("testns").Create(context.TODO(), operatorPod("operator-fghij", "2", false), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			restarted, err = deploymentPodsReady(context.TODO(), clientset, "testns", []string{"operator"}, deleted)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restarted).To(BeFalse())
+
+			_, err = clientset.CoreV1().Pods("testns").Update(context.TODO(), operatorPod("operator-fghij", "2", true), metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			restarted, err = deploymentPodsReady(context.TODO(), clientset, "testns", []string{"operator"}, deleted)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restarted).To(BeTrue())
+		})
+		It("should delete the operator pods and report them", func() {
+			options := &auditOptions{namespace: "testns", csvWaitTime: time.Millisecond}
+			restart := report.OperatorRestart{}
+			Expect(restartOperator(context.TODO(), options, clientset, []string{"operator"}, &restart)).To(Succeed())
+			Expect(restart.DeletedPods).To(Equal([]string{"operator-abcde"}))
+			Expect(restart.Restarted).To(BeFalse())
+
+			_, err := clientset.CoreV1().Pods("testns").Get(context.TODO(), "operator-abcde", metav1.GetOptions{})
+			Expect(err).To(HaveOccurred())
+			_, err = clientset.CoreV1().Pods("testns").Get(context.TODO(), "operand", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+	When("deciding the result", func() {
+		It("should fail when an operand orphaned resources", func() {
+			restart := report.OperatorRestart{Restarted: true, Operands: []report.OperandRestart{{Healthy: true, Changed: []string{"Service/added (created)"}}}}
+			Expect(operatorRestartPassed(restart)).To(BeTrue())
+
+			restart.Operands[0].Orphaned = []string{"ConfigMap/orphan"}
+			Expect(operatorRestartPassed(restart)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,223 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// uninstallGracePeriod is how long cluster scoped objects are given to be garbage collected after cleanup
+const uninstallGracePeriod = time.Minute
+
+// clusterScopedKinds are the kinds an operator may leave behind outside of its namespaces
+var clusterScopedKinds = []schema.GroupVersionKind{
+	apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"),
+	rbacv1.SchemeGroupVersion.WithKind("ClusterRole"),
+	rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"),
+	admissionregistrationv1.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration"),
+	admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration"),
+	{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService"},
+}
+
+// snapshotClusterResources lists the objects of all clusterScopedKinds
+func snapshotClusterResources(ctx context.Context, options *auditOptions) ([]unstructured.Unstructured, error) {
+	objs := []unstructured.Unstructured{}
+	for _, gvk := range clusterScopedKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := options.client.ListUnstructured(ctx, "", list); err != nil {
+			return nil, fmt.Errorf("could not list %s: %v", gvk.Kind, err)
+		}
+		for _, obj := range list.Items {
+			// items of unstructured lists don't always carry their kind
+			obj.SetGroupVersionKind(gvk)
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// olmOwnerLabel and olmOwnerNamespaceLabel are set by OLM on the cluster scoped objects it creates for a CSV
+const (
+	olmOwnerLabel          = "olm.owner"
+	olmOwnerNamespaceLabel = "olm.owner.namespace"
+)
+
+// operatorTied filters objs down to the ones tied to the operator installed from csv in namespace: objects labeled
+// by OLM as owned by a CSV in namespace, and the ClusterRoleBindings granting the CSV's clusterPermissions to its
+// ServiceAccounts along with the ClusterRoles they bind. CRDs are left out since OLM keeps them on uninstall by design.
+func operatorTied(objs []unstructured.Unstructured, csv *operatorv1alpha1.ClusterServiceVersion, namespace string) []unstructured.Unstructured {
+	serviceAccounts := map[string]bool{}
+	for _, permission := range csv.Spec.InstallStrategy.StrategySpec.ClusterPermissions {
+		serviceAccounts[permission.ServiceAccountName] = true
+	}
+
+	tied := map[types.UID]bool{}
+	boundRoles := map[string]bool{}
+	for _, obj := range objs {
+		if obj.GetKind() == "CustomResourceDefinition" {
+			continue
+		}
+		labels := obj.GetLabels()
+		if labels[olmOwnerLabel] != "" && labels[olmOwnerNamespaceLabel] == namespace {
+			tied[obj.GetUID()] = true
+		}
+
+		if obj.GetKind() == "ClusterRoleBinding" {
+			var binding rbacv1.ClusterRoleBinding
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &binding); err != nil {
+				logger.Debugw("could not read ClusterRoleBinding", "error", err, "name", obj.GetName())
+				continue
+			}
+			for _, subject := range binding.Subjects {
+				if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == namespace && serviceAccounts[subject.Name] {
+					tied[obj.GetUID()] = true
+					boundRoles[binding.RoleRef.Name] = true
+				}
+			}
+		}
+	}
+
+	filtered := []unstructured.Unstructured{}
+	for _, obj := range objs {
+		if tied[obj.GetUID()] || (obj.GetKind() == "ClusterRole" && boundRoles[obj.GetName()]) {
+			filtered = append(filtered, obj)
+		}
+	}
+	return filtered
+}
+
+// retainedCRDs returns the names of the CRDs owned by csv that were created after the before snapshot and are
+// still present. OLM doesn't delete them on uninstall so they are only reported for information.
+func retainedCRDs(before map[types.UID]bool, after []unstructured.Unstructured, csv *operatorv1alpha1.ClusterServiceVersion) []string {
+	owned := map[string]bool{}
+	for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+		owned[crd.Name] = true
+	}
+
+	retained := []string{}
+	for _, obj := range after {
+		if obj.GetKind() == "CustomResourceDefinition" && owned[obj.GetName()] && !before[obj.GetUID()] {
+			retained = append(retained, obj.GetName())
+		}
+	}
+	sort.Strings(retained)
+	return retained
+}
+
+// leakedResources returns the objects in after that were not part of the before snapshot
+func leakedResources(before map[types.UID]bool, after []unstructured.Unstructured) []report.LeakedResource {
+	leaked := []report.LeakedResource{}
+	for _, obj := range after {
+		if before[obj.GetUID()] {
+			continue
+		}
+		leaked = append(leaked, report.LeakedResource{
+			Kind:        obj.GetKind(),
+			Name:        obj.GetName(),
+			Terminating: obj.GetDeletionTimestamp() != nil,
+		})
+	}
+	sort.Slice(leaked, func(i, j int) bool {
+		if leaked[i].Kind != leaked[j].Kind {
+			return leaked[i].Kind < leaked[j].Kind
+		}
+		return leaked[i].Name < leaked[j].Name
+	})
+	return leaked
+}
+
+// writeUninstallReports writes the operator uninstall JSON and text reports
+func writeUninstallReports(options *auditOptions, uninstall report.OperatorUninstall) error {
+	return writeReports(options, "operator_uninstall", report.TemplateData{
+		OperatorUninstall: uninstall,
+	}, report.OperatorUninstallJsonReport, report.OperatorUninstallTextReport)
+}
+
+// operatorUninstall snapshots cluster scoped objects before the operator is installed. Its cleanup runs after
+// the operator's own cleanup, since cleanups are run in reverse order, and reports the objects tied to the
+// operator that were left behind. It must be placed before OperatorInstall or OperatorUpgrade in the audit plan,
+// and is reported as skipped otherwise.
+func operatorUninstall(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	var before map[types.UID]bool
+
+	return func(ctx context.Context) error {
+		logger.Debugw("taking cluster snapshot before installing operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csvs, err := options.client.ListClusterServiceVersions(ctx, options.namespace)
+		if err != nil {
+			return fmt.Errorf("could not list CSVs: %v", err)
+		}
+		if len(csvs.Items) > 0 {
+			logger.Infow("skipping OperatorUninstall since the operator is already installed, it must come before OperatorInstall or OperatorUpgrade in the audit plan", "namespace", options.namespace)
+			return writeUninstallReports(&options, report.OperatorUninstall{Skipped: "operator installed before the snapshot was taken"})
+		}
+
+		snapshot, err := snapshotClusterResources(ctx, &options)
+		if err != nil {
+			return fmt.Errorf("could not take cluster snapshot: %v", err)
+		}
+		before = map[types.UID]bool{}
+		for _, obj := range snapshot {
+			before[obj.GetUID()] = true
+		}
+
+		return nil
+	}, operatorUninstallCleanup(&options, &before)
+}
+
+// operatorUninstallCleanup takes the after snapshot once the operator has been cleaned up and writes the reports
+func operatorUninstallCleanup(options *auditOptions, before *map[types.UID]bool) auditCleanupFn {
+	return func(ctx context.Context) error {
+		if *before == nil {
+			// snapshot was never taken, nothing to compare with
+			return nil
+		}
+
+		csv := options.installedCSV
+		if csv == nil || csv.Name == "" {
+			logger.Infow("skipping OperatorUninstall since no operator was installed by the audit plan", "namespace", options.namespace)
+			return writeUninstallReports(options, report.OperatorUninstall{Skipped: "no operator installed by the audit plan"})
+		}
+
+		uninstall := report.OperatorUninstall{}
+		err := wait.PollImmediateWithContext(ctx, 5*time.Second, uninstallGracePeriod, func(ctx context.Context) (bool, error) {
+			after, err := snapshotClusterResources(ctx, options)
+			if err != nil {
+				return false, err
+			}
+			uninstall.Leaked = leakedResources(*before, operatorTied(after, csv, options.namespace))
+			uninstall.RetainedCRDs = retainedCRDs(*before, after, csv)
+			return len(uninstall.Leaked) == 0, nil
+		})
+		if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+			return fmt.Errorf("could not take cluster snapshot: %v", err)
+		}
+
+		for _, leaked := range uninstall.Leaked {
+			logger.Infow("resource left behind by operator", "package", options.subscription.Package, "kind", leaked.Kind, "name", leaked.Name)
+		}
+
+		return writeUninstallReports(options, uninstall)
+	}
+}
@@ -0,0 +1,149 @@
+package capability
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Operator uninstall", func() {
+	Context("leakedResources", func() {
+		It("should report the objects that were not in the before snapshot", func() {
+			before := map[types.UID]bool{"existing-uid": true}
+			terminating := newOwnedObject("ClusterRole", "terminating", "terminating-uid", "")
+			now := metav1.Now()
+			terminating.SetDeletionTimestamp(&now)
+			after := []unstructured.Unstructured{
+				newOwnedObject("CustomResourceDefinition", "existing", "existing-uid", ""),
+				newOwnedObject("CustomResourceDefinition", "leaked", "leaked-uid", ""),
+				terminating,
+			}
+
+			Expect(leakedResources(before, after)).To(Equal([]report.LeakedResource{
+				{Kind: "ClusterRole", Name: "terminating", Terminating: true},
+				{Kind: "CustomResourceDefinition", Name: "leaked"},
+			}))
+		})
+	})
+
+	Context("operatorTied", func() {
+		It("should only keep the objects tied to the operator", func() {
+			csv := &operatorv1alpha1.ClusterServiceVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: "testoperator.v1.0.0", Namespace: "testns"},
+				Spec: operatorv1alpha1.ClusterServiceVersionSpec{
+					CustomResourceDefinitions: operatorv1alpha1.CustomResourceDefinitions{
+						Owned: []operatorv1alpha1.CRDDescription{{Name: "tests.opcap.io"}},
+					},
+					InstallStrategy: operatorv1alpha1.NamedInstallStrategy{
+						StrategySpec: operatorv1alpha1.StrategyDetailsDeployment{
+							ClusterPermissions: []operatorv1alpha1.StrategyDeploymentPermissions{{ServiceAccountName: "testoperator"}},
+						},
+					},
+				},
+			}
+
+			labeled := newOwnedObject("ValidatingWebhookConfiguration", "labeled", "labeled-uid", "")
+			labeled.SetLabels(map[string]string{olmOwnerLabel: "testoperator.v1.0.0", olmOwnerNamespaceLabel: "testns"})
+			otherNamespace := newOwnedObject("ClusterRole", "other-namespace", "other-namespace-uid", "")
+			otherNamespace.SetLabels(map[string]string{olmOwnerLabel: "otheroperator.v1.0.0", olmOwnerNamespaceLabel: "otherns"})
+
+			binding := newOwnedObject("ClusterRoleBinding", "bound", "binding-uid", "")
+			binding.Object["subjects"] = []interface{}{map[string]interface{}{"kind": "ServiceAccount", "name": "testoperator", "namespace": "testns"}}
+			binding.Object["roleRef"] = map[string]interface{}{"apiGroup": "rbac.authorization.k8s.io", "kind": "ClusterRole", "name": "bound-role"}
+			otherBinding := newOwnedObject("ClusterRoleBinding", "other-binding", "other-binding-uid", "")
+			otherBinding.Object["subjects"] = []interface{}{map[string]interface{}{"kind": "ServiceAccount", "name": "testoperator", "namespace": "otherns"}}
+			otherBinding.Object["roleRef"] = map[string]interface{}{"apiGroup": "rbac.authorization.k8s.io", "kind": "ClusterRole", "name": "other-role"}
+
+			objs := []unstructured.Unstructured{
+				labeled,
+				otherNamespace,
+				newOwnedObject("CustomResourceDefinition", "tests.opcap.io", "crd-uid", ""),
+				newOwnedObject("CustomResourceDefinition", "others.opcap.io", "other-crd-uid", ""),
+				binding,
+				otherBinding,
+				newOwnedObject("ClusterRole", "bound-role", "role-uid", ""),
+				newOwnedObject("ClusterRole", "other-role", "other-role-uid", ""),
+			}
+
+			names := []string{}
+			for _, obj := range operatorTied(objs, csv, "testns") {
+				names = append(names, strings.Join([]string{obj.GetKind(), obj.GetName()}, "/"))
+			}
+			Expect(names).To(ConsistOf(
+				"ValidatingWebhookConfiguration/labeled",
+				"ClusterRoleBinding/bound",
+				"ClusterRole/bound-role",
+			))
+		})
+	})
+
+	Context("retainedCRDs", func() {
+		It("should list the new CRDs owned by the CSV that are still present", func() {
+			csv := &operatorv1alpha1.ClusterServiceVersion{
+				Spec: operatorv1alpha1.ClusterServiceVersionSpec{
+					CustomResourceDefinitions: operatorv1alpha1.CustomResourceDefinitions{
+						Owned: []operatorv1alpha1.CRDDescription{{Name: "tests.opcap.io"}, {Name: "existing.opcap.io"}},
+					},
+				},
+			}
+			before := map[types.UID]bool{"existing-uid": true}
+			after := []unstructured.Unstructured{
+				newOwnedObject("CustomResourceDefinition", "tests.opcap.io", "crd-uid", ""),
+				newOwnedObject("CustomResourceDefinition", "existing.opcap.io", "existing-uid", ""),
+				newOwnedObject("CustomResourceDefinition", "others.opcap.io", "other-crd-uid", ""),
+			}
+
+			Expect(retainedCRDs(before, after, csv)).To(Equal([]string{"tests.opcap.io"}))
+		})
+	})
+
+	Context("audit plan order", func() {
+		var options []auditOption
+		var fs afero.Fs
+		var w strings.Builder
+
+		BeforeEach(func() {
+			DeferCleanup(w.Reset)
+			fs = afero.NewMemMapFs()
+			options = []auditOption{
+				withNamespace("testns"),
+				withSubscription(&operator.SubscriptionData{Package: "testoperator"}),
+				withFilesystem(fs),
+				withReportWriter(&w),
+				withInstalledCSV(&operatorv1alpha1.ClusterServiceVersion{}),
+			}
+		})
+		It("should be skipped when the operator is already installed", func() {
+			client := operator.NewFakeOpClient(&operatorv1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "testoperator.v1.0.0", Namespace: "testns"}})
+			auditFn, cleanupFn := operatorUninstall(context.TODO(), append(options, withClient(client))...)
+			Expect(auditFn(context.TODO())).To(Succeed())
+			Expect(w.String()).To(ContainSubstring("Result: Skipped, operator installed before the snapshot was taken"))
+
+			// no snapshot was taken, so the cleanup has nothing to report
+			w.Reset()
+			Expect(cleanupFn(context.TODO())).To(Succeed())
+			Expect(w.String()).To(BeEmpty())
+		})
+		It("should be skipped when no operator was installed by the audit plan", func() {
+			auditFn, cleanupFn := operatorUninstall(context.TODO(), append(options, withClient(operator.NewFakeOpClient()))...)
+			Expect(auditFn(context.TODO())).To(Succeed())
+			Expect(w.String()).To(BeEmpty())
+
+			Expect(cleanupFn(context.TODO())).To(Succeed())
+			Expect(w.String()).To(ContainSubstring("Result: Skipped, no operator installed by the audit plan"))
+			exists, err := afero.Exists(fs, "operator_uninstall_report.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+	})
+})
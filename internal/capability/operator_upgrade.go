@@ -0,0 +1,168 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// approveInstallPlan waits for the InstallPlan that installs csvName and approves it
+func approveInstallPlan(ctx context.Context, options *auditOptions, csvName string) error {
+	installPlan, err := options.client.GetInstallPlanWithTimeout(ctx, csvName, options.namespace, options.csvWaitTime)
+	if err != nil {
+		return fmt.Errorf("could not get installplan for %s: %v", csvName, err)
+	}
+
+	return options.client.ApproveInstallPlan(ctx, installPlan.ObjectMeta.Name, options.namespace)
+}
+
+// recordCSVStatus records the phase of csv, along with its status message when it didn't succeed, e.g. because its
+// pods were rejected by the restricted pod security profile
+func recordCSVStatus(upgrade *report.OperatorUpgrade, csv *operatorv1alpha1.ClusterServiceVersion) {
+	upgrade.Phase = csv.Status.Phase
+	if csv.Status.Phase != operatorv1alpha1.CSVPhaseSucceeded {
+		upgrade.Message = csv.Status.Message
+		upgrade.Reason = csv.Status.Reason
+	}
+}
+
+// waitForChannelHead waits for the CSV at the head of the channel to complete and records its version and phase
+func waitForChannelHead(ctx context.Context, options *auditOptions, channel *operator.ChannelData, upgrade *report.OperatorUpgrade) error {
+	toCSV, err := options.client.GetCompletedCsvByNameWithTimeout(ctx, channel.CurrentCSV, options.namespace, options.csvWaitTime)
+	if err != nil {
+		if !errors.Is(err, operator.TimeoutError) {
+			return err
+		}
+		upgrade.Timeout = true
+	}
+	if toCSV != nil {
+		upgrade.ToVersion = toCSV.Spec.Version.String()
+		recordCSVStatus(upgrade, toCSV)
+		recordInstalledCSV(options, toCSV)
+	}
+	return nil
+}
+
+// installChannelHead subscribes to the channel head as OperatorInstall does, for channels without a previous CSV
+// to upgrade from. The upgrade is reported as skipped.
+func installChannelHead(ctx context.Context, options *auditOptions, channel *operator.ChannelData) (report.OperatorUpgrade, error) {
+	upgrade := report.OperatorUpgrade{
+		ToCSV:   channel.CurrentCSV,
+		Skipped: "no previous CSV in channel",
+	}
+
+	if _, err := options.client.CreateSubscription(ctx, *options.subscription, options.namespace); err != nil {
+		return upgrade, fmt.Errorf("could not create subscription: %v", err)
+	}
+
+	if err := waitForChannelHead(ctx, options, channel, &upgrade); err != nil {
+		return upgrade, err
+	}
+	return upgrade, nil
+}
+
+// upgradeToChannelHead subscribes to previousCSV with manual approval and, once it is installed, approves the
+// upgrade to the channel head
+func upgradeToChannelHead(ctx context.Context, options *auditOptions, channel *operator.ChannelData, previousCSV string) (report.OperatorUpgrade, error) {
+	upgrade := report.OperatorUpgrade{ToCSV: channel.CurrentCSV}
+
+	// subscribe to the previous CSV with manual approval so OLM doesn't go straight to the channel head
+	subscription := *options.subscription
+	subscription.StartingCSV = previousCSV
+	subscription.InstallPlanApproval = operatorv1alpha1.ApprovalManual
+	if _, err := options.client.CreateSubscription(ctx, subscription, options.namespace); err != nil {
+		return upgrade, fmt.Errorf("could not create subscription: %v", err)
+	}
+
+	if err := approveInstallPlan(ctx, options, previousCSV); err != nil {
+		return upgrade, err
+	}
+
+	fromCSV, err := options.client.GetCompletedCsvByNameWithTimeout(ctx, previousCSV, options.namespace, options.csvWaitTime)
+	if errors.Is(err, operator.TimeoutError) {
+		// report the timeout, e.g. OLM lacking permissions in least privilege mode, instead of upgrading
+		upgrade.FromCSV = previousCSV
+		upgrade.Timeout = true
+		recordInstalledCSV(options, fromCSV)
+		return upgrade, nil
+	}
+	if err != nil {
+		return upgrade, fmt.Errorf("could not install previous CSV %s: %v", previousCSV, err)
+	}
+	upgrade.FromCSV = fromCSV.ObjectMeta.Name
+	upgrade.FromVersion = fromCSV.Spec.Version.String()
+	recordInstalledCSV(options, fromCSV)
+	if fromCSV.Status.Phase != operatorv1alpha1.CSVPhaseSucceeded {
+		// report the failed install of the previous CSV instead of upgrading from it
+		recordCSVStatus(&upgrade, fromCSV)
+		return upgrade, nil
+	}
+
+	start := time.Now()
+	if err := approveInstallPlan(ctx, options, channel.CurrentCSV); err != nil {
+		return upgrade, err
+	}
+
+	err = waitForChannelHead(ctx, options, channel, &upgrade)
+	upgrade.Duration = time.Since(start).Round(time.Second)
+	if err != nil {
+		return upgrade, err
+	}
+	return upgrade, nil
+}
+
+// operatorUpgrade installs the CSV that precedes the channel head in the default channel
+// and then approves the upgrade to the channel head. It replaces OperatorInstall in an audit plan.
+// Channels without a previous CSV get the channel head installed so the rest of the audit plan can run.
+func operatorUpgrade(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("upgrading package", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		channel, err := options.client.GetChannelData(ctx, options.subscription.Package, options.subscription.CatalogSource, options.subscription.Channel)
+		if err != nil {
+			return fmt.Errorf("could not get channel data: %v", err)
+		}
+
+		if err := prepareInstall(ctx, &options); err != nil {
+			return err
+		}
+
+		var upgrade report.OperatorUpgrade
+		if previousCSV := channel.PreviousCSV(); previousCSV != "" {
+			upgrade, err = upgradeToChannelHead(ctx, &options, channel, previousCSV)
+		} else {
+			logger.Infow("skipping upgrade and installing the channel head since no previous CSV found in channel", "package", options.subscription.Package, "channel", options.subscription.Channel)
+			upgrade, err = installChannelHead(ctx, &options, channel)
+		}
+		if err != nil {
+			return err
+		}
+
+		leastPrivilege := report.LeastPrivilege{}
+		if options.leastPrivilege {
+			leastPrivilege.ServiceAccount = options.operatorGroupData.ServiceAccountName
+			leastPrivilege.PermissionFailures, err = installPlanFailures(ctx, &options)
+			if err != nil {
+				logger.Errorw("could not read installplan conditions", "error", err, "namespace", options.namespace)
+			}
+		}
+
+		return writeReports(&options, "operator_upgrade", report.TemplateData{
+			RestrictedPodSecurity: options.restrictedPodSecurity,
+			LeastPrivilege:        leastPrivilege,
+			OperatorUpgrade:       upgrade,
+		}, report.OperatorUpgradeJsonReport, report.OperatorUpgradeTextReport)
+	}, operatorCleanup(ctx, opts...)
+}
@@ -0,0 +1,69 @@
+package capability
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+
+	"github.com/blang/semver/v4"
+	"github.com/operator-framework/api/pkg/lib/version"
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func channelHeadCSV(phase operatorv1alpha1.ClusterServiceVersionPhase, message string) *operatorv1alpha1.ClusterServiceVersion {
+	return &operatorv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "testoperator.v1.2.0", Namespace: "testns"},
+		Spec:       operatorv1alpha1.ClusterServiceVersionSpec{Version: version.OperatorVersion{Version: semver.MustParse("1.2.0")}},
+		Status:     operatorv1alpha1.ClusterServiceVersionStatus{Phase: phase, Message: message, Reason: operatorv1alpha1.CSVReasonComponentFailed},
+	}
+}
+
+var _ = Describe("Operator upgrade", func() {
+	When("the channel has no previous CSV", func() {
+		It("should install the channel head and report the upgrade as skipped", func() {
+			client := operator.NewFakeOpClient(channelHeadCSV(operatorv1alpha1.CSVPhaseSucceeded, ""))
+			options := &auditOptions{
+				client:       client,
+				namespace:    "testns",
+				csvWaitTime:  time.Second,
+				subscription: &operator.SubscriptionData{Name: "testsub", Package: "testoperator", Channel: "stable", InstallPlanApproval: operatorv1alpha1.ApprovalAutomatic},
+			}
+			channel := &operator.ChannelData{Name: "stable", CurrentCSV: "testoperator.v1.2.0"}
+
+			upgrade, err := installChannelHead(context.TODO(), options, channel)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(upgrade.Skipped).To(Equal("no previous CSV in channel"))
+			Expect(upgrade.FromCSV).To(BeEmpty())
+			Expect(upgrade.ToVersion).To(Equal("1.2.0"))
+			Expect(upgrade.Phase).To(Equal(operatorv1alpha1.CSVPhaseSucceeded))
+
+			subscription := &unstructured.Unstructured{}
+			subscription.SetGroupVersionKind(operatorv1alpha1.SchemeGroupVersion.WithKind("Subscription"))
+			Expect(client.GetUnstructured(context.TODO(), "testns", "testsub", subscription)).To(Succeed())
+			approval, _, _ := unstructured.NestedString(subscription.Object, "spec", "installPlanApproval")
+			Expect(approval).To(Equal(string(operatorv1alpha1.ApprovalAutomatic)))
+			Expect(upgrade.Message).To(BeEmpty())
+		})
+		It("should record why the channel head failed to install", func() {
+			message := "pods violate PodSecurity restricted:latest"
+			options := &auditOptions{
+				client:       operator.NewFakeOpClient(channelHeadCSV(operatorv1alpha1.CSVPhaseFailed, message)),
+				namespace:    "testns",
+				csvWaitTime:  time.Second,
+				subscription: &operator.SubscriptionData{Name: "testsub", Package: "testoperator", Channel: "stable"},
+			}
+			channel := &operator.ChannelData{Name: "stable", CurrentCSV: "testoperator.v1.2.0"}
+
+			upgrade, err := installChannelHead(context.TODO(), options, channel)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(upgrade.Phase).To(Equal(operatorv1alpha1.CSVPhaseFailed))
+			Expect(upgrade.Message).To(Equal(message))
+			Expect(upgrade.Reason).To(Equal(operatorv1alpha1.CSVReasonComponentFailed))
+		})
+	})
+})
@@ -0,0 +1,202 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// workloadKinds are the kinds an operand is expected to own, directly or through other owned
+// objects. ReplicaSets are listed so that Pods created by Deployments can be traced back to the operand.
+var workloadKinds = []schema.GroupVersionKind{
+	appsv1.SchemeGroupVersion.WithKind("Deployment"),
+	appsv1.SchemeGroupVersion.WithKind("StatefulSet"),
+	appsv1.SchemeGroupVersion.WithKind("DaemonSet"),
+	appsv1.SchemeGroupVersion.WithKind("ReplicaSet"),
+	batchv1.SchemeGroupVersion.WithKind("Job"),
+	corev1.SchemeGroupVersion.WithKind("Pod"),
+	corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"),
+}
+
+// configKinds are the non workload kinds an operand is expected to own
+var configKinds = []schema.GroupVersionKind{
+	corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+	corev1.SchemeGroupVersion.WithKind("Secret"),
+	corev1.SchemeGroupVersion.WithKind("Service"),
+}
+
+// ownedKinds are all the kinds an operand is expected to own
+var ownedKinds = append(append([]schema.GroupVersionKind{}, workloadKinds...), configKinds...)
+
+// listResources lists the objects of the given kinds in namespace
+func listResources(ctx context.Context, options *auditOptions, namespace string, gvks []schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	objs := []unstructured.Unstructured{}
+	for _, gvk := range gvks {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := options.client.ListUnstructured(ctx, namespace, list); err != nil {
+			return nil, fmt.Errorf("could not list %s: %v", gvk.Kind, err)
+		}
+		objs = append(objs, list.Items...)
+	}
+	return objs, nil
+}
+
+// listOwnedResources lists the objects of the given kinds in namespace that are owned by owner
+func listOwnedResources(ctx context.Context, options *auditOptions, namespace string, owner unstructured.Unstructured, gvks []schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	objs, err := listResources(ctx, options, namespace, gvks)
+	if err != nil {
+		return nil, err
+	}
+
+	return ownedBy(objs, owner.GetUID()), nil
+}
+
+// ownedBy filters objs down to the ones that have ownerUID somewhere in their chain of ownerReferences
+func ownedBy(objs []unstructured.Unstructured, ownerUID types.UID) []unstructured.Unstructured {
+	byUID := map[types.UID]unstructured.Unstructured{}
+	for _, obj := range objs {
+		byUID[obj.GetUID()] = obj
+	}
+
+	var isOwned func(obj unstructured.Unstructured, visited map[types.UID]bool) bool
+	isOwned = func(obj unstructured.Unstructured, visited map[types.UID]bool) bool {
+		for _, ref := range obj.GetOwnerReferences() {
+			if ref.UID == ownerUID {
+				return true
+			}
+			if visited[ref.UID] {
+				continue
+			}
+			visited[ref.UID] = true
+			if parent, ok := byUID[ref.UID]; ok && isOwned(parent, visited) {
+				return true
+			}
+		}
+		return false
+	}
+
+	owned := []unstructured.Unstructured{}
+	for _, obj := range objs {
+		if isOwned(obj, map[types.UID]bool{}) {
+			owned = append(owned, obj)
+		}
+	}
+	return owned
+}
+
+// changedResources compares two listings of owned objects and returns the ones that were created, deleted or
+// had their spec updated in between. Objects without a generation are compared by resourceVersion, except Pods
+// whose resourceVersion changes on every status update.
+func changedResources(before, after []unstructured.Unstructured) []string {
+	previous := map[types.UID]unstructured.Unstructured{}
+	for _, obj := range before {
+		previous[obj.GetUID()] = obj
+	}
+
+	changed := []string{}
+	for _, obj := range after {
+		key := strings.Join([]string{obj.GetKind(), obj.GetName()}, "/")
+		old, ok := previous[obj.GetUID()]
+		delete(previous, obj.GetUID())
+		switch {
+		case !ok:
+			changed = append(changed, key+" (created)")
+		case obj.GetKind() == "Pod":
+			continue
+		case obj.GetGeneration() != 0 && obj.GetGeneration() != old.GetGeneration():
+			changed = append(changed, key+" (updated)")
+		case obj.GetGeneration() == 0 && obj.GetResourceVersion() != old.GetResourceVersion():
+			changed = append(changed, key+" (updated)")
+		}
+	}
+	for _, obj := range before {
+		if _, ok := previous[obj.GetUID()]; ok {
+			changed = append(changed, strings.Join([]string{obj.GetKind(), obj.GetName()}, "/")+" (deleted)")
+		}
+	}
+
+	return changed
+}
+
+// resourceReady tells if an owned object reached its ready state. Kinds without a readiness notion are always ready.
+func resourceReady(obj unstructured.Unstructured) (bool, error) {
+	switch obj.GetKind() {
+	case "Deployment":
+		var deployment appsv1.Deployment
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deployment); err != nil {
+			return false, err
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas == replicas &&
+			deployment.Status.AvailableReplicas == replicas, nil
+
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &statefulSet); err != nil {
+			return false, err
+		}
+		replicas := int32(1)
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+		return statefulSet.Status.ObservedGeneration >= statefulSet.Generation &&
+			statefulSet.Status.ReadyReplicas == replicas, nil
+
+	case "DaemonSet":
+		var daemonSet appsv1.DaemonSet
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &daemonSet); err != nil {
+			return false, err
+		}
+		return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+			daemonSet.Status.NumberReady == daemonSet.Status.DesiredNumberScheduled, nil
+
+	case "Job":
+		var job batchv1.Job
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &job); err != nil {
+			return false, err
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobComplete && condition.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case "Pod":
+		var pod corev1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+			return false, err
+		}
+		if pod.Status.Phase == corev1.PodSucceeded {
+			return true, nil
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case "PersistentVolumeClaim":
+		var pvc corev1.PersistentVolumeClaim
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pvc); err != nil {
+			return false, err
+		}
+		return pvc.Status.Phase == corev1.ClaimBound, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,100 @@
+package capability
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newOwnedObject(kind, name string, uid types.UID, ownerUID types.UID) unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetUID(uid)
+	if ownerUID != "" {
+		obj.SetOwnerReferences([]metav1.OwnerReference{{UID: ownerUID, Name: "owner", Kind: "Owner", APIVersion: "v1"}})
+	}
+	return obj
+}
+
+var _ = Describe("Owned resources", func() {
+	Context("ownedBy", func() {
+		It("should follow the owner chain back to the operand", func() {
+			objs := []unstructured.Unstructured{
+				newOwnedObject("Deployment", "test", "deployment-uid", "operand-uid"),
+				newOwnedObject("ReplicaSet", "test-abc", "replicaset-uid", "deployment-uid"),
+				newOwnedObject("Pod", "test-abc-xyz", "pod-uid", "replicaset-uid"),
+				newOwnedObject("Pod", "unrelated", "unrelated-uid", "other-uid"),
+				newOwnedObject("PersistentVolumeClaim", "orphan", "orphan-uid", ""),
+			}
+			owned := ownedBy(objs, "operand-uid")
+			Expect(owned).To(HaveLen(3))
+			for _, obj := range owned {
+				Expect(obj.GetName()).To(HavePrefix("test"))
+			}
+		})
+	})
+
+	Context("changedResources", func() {
+		It("should report created, updated and deleted resources", func() {
+			deployment := newOwnedObject("Deployment", "test", "deployment-uid", "operand-uid")
+			deployment.SetGeneration(1)
+			configMap := newOwnedObject("ConfigMap", "test", "configmap-uid", "operand-uid")
+			configMap.SetResourceVersion("100")
+			pod := newOwnedObject("Pod", "test-abc", "pod-uid", "operand-uid")
+			pod.SetResourceVersion("200")
+			service := newOwnedObject("Service", "test", "service-uid", "operand-uid")
+			before := []unstructured.Unstructured{deployment, configMap, pod, service}
+
+			updatedDeployment := *deployment.DeepCopy()
+			updatedDeployment.SetGeneration(2)
+			updatedPod := *pod.DeepCopy()
+			updatedPod.SetResourceVersion("201")
+			newPod := newOwnedObject("Pod", "test-def", "new-pod-uid", "operand-uid")
+			after := []unstructured.Unstructured{updatedDeployment, configMap, updatedPod, newPod}
+
+			Expect(changedResources(before, after)).To(ConsistOf(
+				"Deployment/test (updated)",
+				"Pod/test-def (created)",
+				"Service/test (deleted)",
+			))
+		})
+	})
+
+	Context("resourceReady", func() {
+		When("a deployment has all replicas available", func() {
+			It("should be ready", func() {
+				obj := newOwnedObject("Deployment", "test", "deployment-uid", "operand-uid")
+				Expect(unstructured.SetNestedField(obj.Object, int64(2), "spec", "replicas")).To(Succeed())
+				Expect(unstructured.SetNestedField(obj.Object, int64(2), "status", "updatedReplicas")).To(Succeed())
+				Expect(unstructured.SetNestedField(obj.Object, int64(2), "status", "availableReplicas")).To(Succeed())
+				Expect(resourceReady(obj)).To(BeTrue())
+			})
+		})
+		When("a deployment has unavailable replicas", func() {
+			It("should not be ready", func() {
+				obj := newOwnedObject("Deployment", "test", "deployment-uid", "operand-uid")
+				Expect(unstructured.SetNestedField(obj.Object, int64(2), "spec", "replicas")).To(Succeed())
+				Expect(unstructured.SetNestedField(obj.Object, int64(2), "status", "updatedReplicas")).To(Succeed())
+				Expect(unstructured.SetNestedField(obj.Object, int64(1), "status", "availableReplicas")).To(Succeed())
+				Expect(resourceReady(obj)).To(BeFalse())
+			})
+		})
+		When("a PVC is bound", func() {
+			It("should be ready", func() {
+				obj := newOwnedObject("PersistentVolumeClaim", "test", "pvc-uid", "operand-uid")
+				Expect(unstructured.SetNestedField(obj.Object, "Bound", "status", "phase")).To(Succeed())
+				Expect(resourceReady(obj)).To(BeTrue())
+			})
+		})
+		When("a pod is not ready", func() {
+			It("should not be ready", func() {
+				obj := newOwnedObject("Pod", "test", "pod-uid", "operand-uid")
+				Expect(unstructured.SetNestedField(obj.Object, "Running", "status", "phase")).To(Succeed())
+				Expect(resourceReady(obj)).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,160 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// sccAnnotation is set by OpenShift on admitted pods with the name of the SecurityContextConstraints used
+const sccAnnotation = "openshift.io/scc"
+
+// restrictedSCCs are the SecurityContextConstraints that don't grant any privilege over the restricted profile
+var restrictedSCCs = map[string]bool{
+	"restricted":    true,
+	"restricted-v2": true,
+	"nonroot":       true,
+	"nonroot-v2":    true,
+}
+
+// inspectContainerSecurity reads the effective security settings of a container, falling back to the pod security
+// context where the container doesn't override it, and lists the settings that aren't allowed by the restricted profile
+func inspectContainerSecurity(pod corev1.Pod, container corev1.Container) report.ContainerSecurity {
+	security := report.ContainerSecurity{
+		Name:                     container.Name,
+		HostNetwork:              pod.Spec.HostNetwork,
+		HostPID:                  pod.Spec.HostPID,
+		AllowPrivilegeEscalation: true,
+	}
+
+	podContext := pod.Spec.SecurityContext
+	if podContext == nil {
+		podContext = &corev1.PodSecurityContext{}
+	}
+	containerContext := container.SecurityContext
+	if containerContext == nil {
+		containerContext = &corev1.SecurityContext{}
+	}
+
+	if containerContext.RunAsNonRoot != nil {
+		security.RunAsNonRoot = *containerContext.RunAsNonRoot
+	} else if podContext.RunAsNonRoot != nil {
+		security.RunAsNonRoot = *podContext.RunAsNonRoot
+	}
+	if containerContext.Privileged != nil {
+		security.Privileged = *containerContext.Privileged
+	}
+	if containerContext.AllowPrivilegeEscalation != nil {
+		security.AllowPrivilegeEscalation = *containerContext.AllowPrivilegeEscalation
+	}
+	if containerContext.Capabilities != nil {
+		for _, capability := range containerContext.Capabilities.Add {
+			security.CapabilitiesAdded = append(security.CapabilitiesAdded, string(capability))
+		}
+	}
+	if containerContext.SeccompProfile != nil {
+		security.SeccompProfile = string(containerContext.SeccompProfile.Type)
+	} else if podContext.SeccompProfile != nil {
+		security.SeccompProfile = string(podContext.SeccompProfile.Type)
+	}
+
+	hostPathVolumes := map[string]bool{}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil {
+			hostPathVolumes[volume.Name] = true
+		}
+	}
+	for _, mount := range container.VolumeMounts {
+		if hostPathVolumes[mount.Name] {
+			security.HostPathVolumes = append(security.HostPathVolumes, mount.Name)
+		}
+	}
+
+	if !security.RunAsNonRoot {
+		security.Findings = append(security.Findings, "runAsNonRoot is not true")
+	}
+	if security.Privileged {
+		security.Findings = append(security.Findings, "privileged")
+	}
+	if security.HostNetwork {
+		security.Findings = append(security.Findings, "hostNetwork")
+	}
+	if security.HostPID {
+		security.Findings = append(security.Findings, "hostPID")
+	}
+	for _, volume := range security.HostPathVolumes {
+		security.Findings = append(security.Findings, "hostPath volume "+volume)
+	}
+	if security.AllowPrivilegeEscalation {
+		security.Findings = append(security.Findings, "allowPrivilegeEscalation is not false")
+	}
+	for _, capability := range security.CapabilitiesAdded {
+		if capability != "NET_BIND_SERVICE" {
+			security.Findings = append(security.Findings, "capability added "+capability)
+		}
+	}
+	if security.SeccompProfile != string(corev1.SeccompProfileTypeRuntimeDefault) && security.SeccompProfile != string(corev1.SeccompProfileTypeLocalhost) {
+		security.Findings = append(security.Findings, "seccompProfile is not RuntimeDefault or Localhost")
+	}
+
+	return security
+}
+
+// inspectPodSecurity reads the security posture of all the containers of a pod. A pod is restricted when it was
+// admitted under a restricted SCC and none of its containers has findings.
+func inspectPodSecurity(pod corev1.Pod) report.PodSecurity {
+	security := report.PodSecurity{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		SCC:       pod.Annotations[sccAnnotation],
+	}
+	security.RestrictedSCC = restrictedSCCs[security.SCC]
+
+	security.Restricted = security.RestrictedSCC
+	for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		containerSecurity := inspectContainerSecurity(pod, container)
+		security.Containers = append(security.Containers, containerSecurity)
+		security.Restricted = security.Restricted && len(containerSecurity.Findings) == 0
+	}
+
+	return security
+}
+
+// podSecurity reports the security posture of every pod running in the operator's own and target namespaces
+func podSecurity(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking pod security for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		pods := []report.PodSecurity{}
+		for _, ns := range auditNamespaces(&options) {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PodList"))
+			if err := options.client.ListUnstructured(ctx, ns, list); err != nil {
+				return fmt.Errorf("could not list pods in namespace %s: %v", ns, err)
+			}
+
+			for _, obj := range list.Items {
+				var pod corev1.Pod
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+					return fmt.Errorf("could not read pod %s: %v", obj.GetName(), err)
+				}
+				pods = append(pods, inspectPodSecurity(pod))
+			}
+		}
+
+		return writeReports(&options, "pod_security", report.TemplateData{
+			PodSecurity: pods,
+		}, report.PodSecurityJsonReport, report.PodSecurityTextReport)
+	}, noCleanup
+}
@@ -0,0 +1,100 @@
+package capability
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func boolPointer(b bool) *bool {
+	return &b
+}
+
+var _ = Describe("Pod security", func() {
+	var pod corev1.Pod
+
+	BeforeEach(func() {
+		pod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "testpod",
+				Namespace:   "testns",
+				Annotations: map[string]string{sccAnnotation: "restricted-v2"},
+			},
+			Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot:   boolPointer(true),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+				Containers: []corev1.Container{
+					{
+						Name: "manager",
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: boolPointer(false),
+							Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	When("the pod follows the restricted profile", func() {
+		It("should be restricted", func() {
+			security := inspectPodSecurity(pod)
+			Expect(security.RestrictedSCC).To(BeTrue())
+			Expect(security.Restricted).To(BeTrue())
+			Expect(security.Containers).To(HaveLen(1))
+			Expect(security.Containers[0].Findings).To(BeEmpty())
+			Expect(security.Containers[0].SeccompProfile).To(Equal("RuntimeDefault"))
+		})
+	})
+	When("a container overrides the pod security context", func() {
+		It("should report a finding per setting", func() {
+			pod.Annotations[sccAnnotation] = "privileged"
+			pod.Spec.HostNetwork = true
+			pod.Spec.Volumes = []corev1.Volume{
+				{Name: "host", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run"}}},
+				{Name: "config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			}
+			pod.Spec.InitContainers = []corev1.Container{
+				{
+					Name: "init",
+					SecurityContext: &corev1.SecurityContext{
+						RunAsNonRoot:             boolPointer(false),
+						Privileged:               boolPointer(true),
+						AllowPrivilegeEscalation: boolPointer(false),
+						Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}},
+						SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+					},
+					VolumeMounts: []corev1.VolumeMount{{Name: "host"}, {Name: "config"}},
+				},
+			}
+
+			security := inspectPodSecurity(pod)
+			Expect(security.RestrictedSCC).To(BeFalse())
+			Expect(security.Restricted).To(BeFalse())
+			Expect(security.Containers).To(HaveLen(2))
+			Expect(security.Containers[0].Name).To(Equal("init"))
+			Expect(security.Containers[0].HostPathVolumes).To(Equal([]string{"host"}))
+			Expect(security.Containers[0].Findings).To(Equal([]string{
+				"runAsNonRoot is not true",
+				"privileged",
+				"hostNetwork",
+				"hostPath volume host",
+				"capability added SYS_ADMIN",
+				"seccompProfile is not RuntimeDefault or Localhost",
+			}))
+			Expect(security.Containers[1].Findings).To(Equal([]string{"hostNetwork"}))
+		})
+	})
+	When("allowPrivilegeEscalation is not set", func() {
+		It("should default to allowed", func() {
+			pod.Spec.Containers[0].SecurityContext = nil
+
+			security := inspectPodSecurity(pod)
+			Expect(security.Containers[0].AllowPrivilegeEscalation).To(BeTrue())
+			Expect(security.Containers[0].Findings).To(ContainElement("allowPrivilegeEscalation is not false"))
+		})
+	})
+})
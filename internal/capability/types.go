@@ -14,20 +14,24 @@ import (
 )
 
 type auditOptions struct {
-	subscription      *operator.SubscriptionData
-	operatorGroupData *operator.OperatorGroupData
-	namespace         string
-	client            operator.Client
-	csvTimeout        bool
-	csvWaitTime       time.Duration
-	csv               *v1alpha1.ClusterServiceVersion
-	ocpVersion        string
-	customResources   []map[string]interface{}
-	operands          []unstructured.Unstructured
-	fs                afero.Fs
-	reportWriter      io.Writer
-	csvEvents         *corev1.EventList
-	detailedReports   bool
+	subscription          *operator.SubscriptionData
+	operatorGroupData     *operator.OperatorGroupData
+	namespace             string
+	client                operator.Client
+	csvTimeout            bool
+	csvWaitTime           time.Duration
+	csv                   *v1alpha1.ClusterServiceVersion
+	installedCSV          *v1alpha1.ClusterServiceVersion
+	ocpVersion            string
+	customResources       []map[string]interface{}
+	operands              *[]unstructured.Unstructured
+	operandChanges        []map[string]interface{}
+	fs                    afero.Fs
+	reportWriter          io.Writer
+	csvEvents             *corev1.EventList
+	detailedReports       bool
+	restrictedPodSecurity bool
+	leastPrivilege        bool
 }
 
 type auditorOptions struct {
@@ -52,6 +56,10 @@ type auditorOptions struct {
 	// to be audited by the OperandInstall AuditPlan.
 	extraCustomResources string
 
+	// operandChanges is a directory of partial Custom Resources per package, applied to the operands
+	// by the OperandReconfiguration AuditPlan.
+	operandChanges string
+
 	// OpCapClient is the main OpenShift client interface
 	opCapClient operator.Client
 
@@ -66,6 +74,12 @@ type auditorOptions struct {
 
 	// DetailedReports creates reports containing events and logs
 	detailedReports bool
+
+	// RestrictedPodSecurity enforces the restricted Pod Security Admission profile on the audit namespaces
+	restrictedPodSecurity bool
+
+	// LeastPrivilege scopes the OperatorGroup to a ServiceAccount with namespace-only permissions
+	leastPrivilege bool
 }
 
 type (
@@ -0,0 +1,196 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// unwatchedObservationPeriod is the minimum time operands in the unwatched namespace are observed for
+const unwatchedObservationPeriod = 30 * time.Second
+
+// unwatchedNamespace returns the name of the namespace outside of the OperatorGroup used by the WatchScope audit
+func unwatchedNamespace(namespace string) string {
+	return strings.Join([]string{namespace, "unwatched"}, "-")
+}
+
+// reconciledByStatus tells if an operator has touched an operand, either by writing its status or by adding
+// finalizers to it
+func reconciledByStatus(obj unstructured.Unstructured) bool {
+	status, _, _ := unstructured.NestedMap(obj.Object, "status")
+	return len(status) > 0 || len(obj.GetFinalizers()) > 0
+}
+
+// operandReconciled tells if an operand was reconciled, looking at the operand itself and at the resources it owns
+func operandReconciled(ctx context.Context, options *auditOptions, operand unstructured.Unstructured) (bool, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(operand.GroupVersionKind())
+	if err := options.client.GetUnstructured(ctx, operand.GetNamespace(), operand.GetName(), obj); err != nil {
+		return false, err
+	}
+	if reconciledByStatus(*obj) {
+		return true, nil
+	}
+
+	owned, err := listOwnedResources(ctx, options, obj.GetNamespace(), *obj, ownedKinds)
+	if err != nil {
+		return false, err
+	}
+	return len(owned) > 0, nil
+}
+
+// watchScopePassed tells if the operator reconciled exactly the operands in its target namespaces
+func watchScopePassed(operands []report.ScopedOperand) bool {
+	if len(operands) == 0 {
+		return false
+	}
+	for _, operand := range operands {
+		if operand.Error != "" || operand.Reconciled != operand.Watched {
+			return false
+		}
+	}
+	return true
+}
+
+// watchScope creates the ALM examples in each target namespace and in a namespace outside of the OperatorGroup,
+// then checks that the operator only reconciles the ones it watches. It only applies to the SingleNamespace and
+// MultiNamespace install modes.
+func watchScope(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	created := []unstructured.Unstructured{}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking watch scope for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		if options.subscription.InstallModeType != operatorv1alpha1.InstallModeTypeSingleNamespace &&
+			options.subscription.InstallModeType != operatorv1alpha1.InstallModeTypeMultiNamespace {
+			logger.Infow("exiting WatchScope since it only applies to SingleNamespace and MultiNamespace install modes", "installmode", options.subscription.InstallModeType)
+			return nil
+		}
+
+		if err := extractAlmExamples(ctx, &options); err != nil {
+			logger.Errorf("could not get ALM Examples: %v", err)
+		}
+		if len(options.customResources) == 0 {
+			logger.Infow("exiting WatchScope since no ALM_Examples found in CSV")
+			return nil
+		}
+
+		unwatched := unwatchedNamespace(options.namespace)
+		if _, err := options.client.CreateNamespace(ctx, unwatched); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("could not create unwatched namespace: %v", err)
+		}
+		if options.restrictedPodSecurity {
+			if err := options.client.LabelNamespace(ctx, unwatched, restrictedPodSecurityLabels); err != nil {
+				return fmt.Errorf("could not enforce restricted pod security: %v", err)
+			}
+		}
+
+		operands := []report.ScopedOperand{}
+		// objects holds the created object of each operand, nil when creation failed
+		objects := []*unstructured.Unstructured{}
+		namespaces := append(append([]string{}, options.operatorGroupData.TargetNamespaces...), unwatched)
+		for _, cr := range options.customResources {
+			for _, ns := range namespaces {
+				obj := (&unstructured.Unstructured{Object: cr}).DeepCopy()
+				obj.SetNamespace(ns)
+
+				operand := report.ScopedOperand{
+					Kind:      obj.GetKind(),
+					Name:      obj.GetName(),
+					Namespace: ns,
+					Watched:   ns != unwatched,
+				}
+				if err := options.client.CreateUnstructured(ctx, obj); err != nil {
+					logger.Errorw("could not create resource", "error", err, "namespace", ns)
+					operand.Error = err.Error()
+					obj = nil
+				} else {
+					created = append(created, *obj)
+				}
+				operands = append(operands, operand)
+				objects = append(objects, obj)
+			}
+		}
+		start := time.Now()
+
+		// wait for the watched operands to be reconciled, and for at least the observation period for the
+		// unwatched ones to be left alone
+		err := wait.PollImmediateWithContext(ctx, 5*time.Second, options.csvWaitTime, func(ctx context.Context) (bool, error) {
+			done := time.Since(start) >= unwatchedObservationPeriod
+			for i, obj := range objects {
+				if obj == nil || !operands[i].Watched {
+					continue
+				}
+				ok, err := operandReconciled(ctx, &options, *obj)
+				if err != nil {
+					return false, err
+				}
+				done = done && ok
+			}
+			return done, nil
+		})
+		if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+			return fmt.Errorf("could not check operands: %v", err)
+		}
+
+		for i, obj := range objects {
+			if obj == nil {
+				continue
+			}
+			operands[i].Reconciled, err = operandReconciled(ctx, &options, *obj)
+			if err != nil {
+				operands[i].Error = err.Error()
+			}
+		}
+
+		watchScope := report.WatchScope{
+			TargetNamespaces:   options.operatorGroupData.TargetNamespaces,
+			UnwatchedNamespace: unwatched,
+			Operands:           operands,
+		}
+		watchScope.Passed = watchScopePassed(operands)
+
+		return writeReports(&options, "watch_scope", report.TemplateData{
+			WatchScope: watchScope,
+		}, report.WatchScopeJsonReport, report.WatchScopeTextReport)
+	}, watchScopeCleanup(&options, &created)
+}
+
+// watchScopeCleanup deletes the operands created by the WatchScope audit and the unwatched namespace. It runs
+// before the operator's own cleanup, so the operator can still handle the finalizers of the watched operands.
+func watchScopeCleanup(options *auditOptions, created *[]unstructured.Unstructured) auditCleanupFn {
+	return func(ctx context.Context) error {
+		if len(*created) == 0 {
+			return nil
+		}
+
+		errs := []error{}
+		for _, obj := range *created {
+			if err := removeOperand(ctx, options, obj); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if err := options.client.DeleteNamespace(ctx, unwatchedNamespace(options.namespace)); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("could not delete unwatched namespace: %v", err))
+		}
+
+		return utilerrors.NewAggregate(errs)
+	}
+}
@@ -0,0 +1,68 @@
+package capability
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+	"github.com/opdev/opcap/internal/report"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Watch scope", func() {
+	When("looking at an operand", func() {
+		It("should be reconciled once the operator wrote its status or added finalizers", func() {
+			obj := newOwnedObject("Owner", "operand", "operand-uid", "")
+			Expect(reconciledByStatus(obj)).To(BeFalse())
+
+			Expect(unstructured.SetNestedMap(obj.Object, map[string]interface{}{}, "status")).To(Succeed())
+			Expect(reconciledByStatus(obj)).To(BeFalse())
+
+			obj.SetFinalizers([]string{"test.opcap.io/finalizer"})
+			Expect(reconciledByStatus(obj)).To(BeTrue())
+
+			obj.SetFinalizers(nil)
+			Expect(unstructured.SetNestedField(obj.Object, "Ready", "status", "phase")).To(Succeed())
+			Expect(reconciledByStatus(obj)).To(BeTrue())
+		})
+	})
+	When("deciding the result", func() {
+		It("should pass only when exactly the watched operands were reconciled", func() {
+			operands := []report.ScopedOperand{
+				{Namespace: "watched", Watched: true, Reconciled: true},
+				{Namespace: "unwatched", Watched: false, Reconciled: false},
+			}
+			Expect(watchScopePassed(operands)).To(BeTrue())
+
+			operands[1].Reconciled = true
+			Expect(watchScopePassed(operands)).To(BeFalse())
+
+			operands[1].Reconciled = false
+			operands[0].Reconciled = false
+			Expect(watchScopePassed(operands)).To(BeFalse())
+
+			Expect(watchScopePassed(nil)).To(BeFalse())
+		})
+	})
+	When("cleaning up", func() {
+		It("should delete the created operands and the unwatched namespace", func() {
+			operand := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "operand", Namespace: unwatchedNamespace("test")}}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: unwatchedNamespace("test")}}
+			client := operator.NewFakeOpClient(operand, namespace)
+			options := &auditOptions{client: client, namespace: "test"}
+
+			obj := unstructured.Unstructured{}
+			obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+			obj.SetNamespace(operand.Namespace)
+			obj.SetName(operand.Name)
+
+			Expect(watchScopeCleanup(options, &[]unstructured.Unstructured{obj})(context.Background())).To(Succeed())
+			Expect(apierrors.IsNotFound(client.GetUnstructured(context.Background(), operand.Namespace, operand.Name, &obj))).To(BeTrue())
+			Expect(apierrors.IsNotFound(client.DeleteNamespace(context.Background(), namespace.Name))).To(BeTrue())
+		})
+	})
+})
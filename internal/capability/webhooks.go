@@ -0,0 +1,341 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opdev/opcap/internal/logger"
+	"github.com/opdev/opcap/internal/report"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// webhookDescriptionLabel is set by OLM on the webhook configurations it creates for a CSV webhook definition
+const webhookDescriptionLabel = "olm.webhook-description-generate-name"
+
+// webhookService is the service an admission or conversion webhook calls
+type webhookService struct {
+	namespace string
+	name      string
+}
+
+// admissionWebhookConfigurations returns the names of the webhook configurations OLM created for an admission
+// webhook definition and the service of the webhook
+func admissionWebhookConfigurations(ctx context.Context, clientset kubernetes.Interface, namespace string, desc operatorv1alpha1.WebhookDescription) ([]string, *webhookService, error) {
+	listOptions := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			webhookDescriptionLabel: desc.GenerateName,
+			olmOwnerNamespaceLabel:  namespace,
+		}).String(),
+	}
+
+	configurations := []string{}
+	var service *webhookService
+	found := func(name string, clientConfig admissionregistrationv1.WebhookClientConfig) {
+		configurations = append(configurations, name)
+		if clientConfig.Service != nil && service == nil {
+			service = &webhookService{namespace: clientConfig.Service.Namespace, name: clientConfig.Service.Name}
+		}
+	}
+
+	switch desc.Type {
+	case operatorv1alpha1.ValidatingAdmissionWebhook:
+		list, err := clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, listOptions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not list validating webhook configurations: %v", err)
+		}
+		for _, configuration := range list.Items {
+			for _, webhook := range configuration.Webhooks {
+				if webhook.Name == desc.GenerateName {
+					found(configuration.Name, webhook.ClientConfig)
+				}
+			}
+		}
+
+	case operatorv1alpha1.MutatingAdmissionWebhook:
+		list, err := clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, listOptions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not list mutating webhook configurations: %v", err)
+		}
+		for _, configuration := range list.Items {
+			for _, webhook := range configuration.Webhooks {
+				if webhook.Name == desc.GenerateName {
+					found(configuration.Name, webhook.ClientConfig)
+				}
+			}
+		}
+	}
+
+	return configurations, service, nil
+}
+
+// conversionWebhookCRDs returns the CRDs of a conversion webhook definition that convert through a webhook, the
+// ones that don't and the service of the webhook
+func conversionWebhookCRDs(desc operatorv1alpha1.WebhookDescription, crds []apiextensionsv1.CustomResourceDefinition) ([]string, []string, *webhookService) {
+	byName := map[string]apiextensionsv1.CustomResourceDefinition{}
+	for _, crd := range crds {
+		byName[crd.Name] = crd
+	}
+
+	converted, missing := []string{}, []string{}
+	var service *webhookService
+	for _, name := range desc.ConversionCRDs {
+		crd, ok := byName[name]
+		if !ok || crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != apiextensionsv1.WebhookConverter ||
+			crd.Spec.Conversion.Webhook == nil || crd.Spec.Conversion.Webhook.ClientConfig == nil {
+			missing = append(missing, name)
+			continue
+		}
+		converted = append(converted, name)
+		if s := crd.Spec.Conversion.Webhook.ClientConfig.Service; s != nil && service == nil {
+			service = &webhookService{namespace: s.Namespace, name: s.Name}
+		}
+	}
+
+	return converted, missing, service
+}
+
+// readyEndpoints counts the ready addresses behind a service
+func readyEndpoints(ctx context.Context, clientset kubernetes.Interface, service webhookService) (int, error) {
+	endpoints, err := clientset.CoreV1().Endpoints(service.namespace).Get(ctx, service.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ready := 0
+	for _, subset := range endpoints.Subsets {
+		ready += len(subset.Addresses)
+	}
+	return ready, nil
+}
+
+// ruleMatches tells if an admission rule intercepts the creation of a resource
+func ruleMatches(rule admissionregistrationv1.RuleWithOperations, group, version, resource string) bool {
+	contains := func(values []string, value string) bool {
+		for _, v := range values {
+			if v == "*" || v == value {
+				return true
+			}
+		}
+		return false
+	}
+
+	operations := []string{}
+	for _, operation := range rule.Operations {
+		operations = append(operations, string(operation))
+	}
+
+	return contains(operations, string(admissionregistrationv1.Create)) &&
+		contains(rule.APIGroups, group) &&
+		contains(rule.APIVersions, version) &&
+		contains(rule.Resources, resource)
+}
+
+// breakField sets the first string or number found under fields, in sorted key order, to a value an operator is
+// not expected to accept while keeping its type, so that the operand still passes the CRD schema. It returns the
+// path of the broken field.
+func breakField(fields map[string]interface{}, path []string) []string {
+	keys := []string{}
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := append(append([]string{}, path...), key)
+		switch value := fields[key].(type) {
+		case string:
+			fields[key] = "opcap-invalid"
+			return fieldPath
+		case int64, float64:
+			fields[key] = int64(-1)
+			return fieldPath
+		case map[string]interface{}:
+			if broken := breakField(value, fieldPath); broken != nil {
+				return broken
+			}
+		}
+	}
+	return nil
+}
+
+// invalidOperand returns a deep copy of the first ALM example intercepted by the webhook rules, named after the
+// example and with one field of its spec broken, along with the path of that field
+func invalidOperand(desc operatorv1alpha1.WebhookDescription, customResources []map[string]interface{}, crds []apiextensionsv1.CustomResourceDefinition) (*unstructured.Unstructured, string) {
+	for _, cr := range customResources {
+		example := unstructured.Unstructured{Object: cr}
+		gvk := example.GroupVersionKind()
+
+		var crd *apiextensionsv1.CustomResourceDefinition
+		for i := range crds {
+			if crds[i].Spec.Group == gvk.Group && crds[i].Spec.Names.Kind == gvk.Kind {
+				crd = &crds[i]
+			}
+		}
+		if crd == nil {
+			continue
+		}
+
+		for _, rule := range desc.Rules {
+			if !ruleMatches(rule, gvk.Group, gvk.Version, crd.Spec.Names.Plural) {
+				continue
+			}
+			obj := example.DeepCopy()
+			unstructured.RemoveNestedField(obj.Object, "metadata")
+			unstructured.RemoveNestedField(obj.Object, "status")
+			obj.SetName(strings.Join([]string{example.GetName(), "opcap", "invalid"}, "-"))
+
+			spec, found, _ := unstructured.NestedMap(obj.Object, "spec")
+			if !found {
+				break
+			}
+			field := breakField(spec, []string{"spec"})
+			if field == nil {
+				break
+			}
+			obj.Object["spec"] = spec
+			return obj, strings.Join(field, ".")
+		}
+	}
+	return nil, ""
+}
+
+// classifyRejection tells which admission step, if any, refused an operand
+func classifyRejection(err error) string {
+	switch {
+	case err == nil:
+		return "accepted"
+	case strings.Contains(err.Error(), "failed calling webhook"):
+		return "webhook unreachable"
+	case strings.Contains(err.Error(), "admission webhook") && strings.Contains(err.Error(), "denied the request"):
+		return "rejected by webhook"
+	case apierrors.IsInvalid(err):
+		return "rejected by schema"
+	default:
+		return "error"
+	}
+}
+
+// sendInvalidOperand creates an operand with a broken field and reports whether the validating webhook rejects it.
+// An accepted operand is deleted right away.
+func sendInvalidOperand(ctx context.Context, options *auditOptions, obj *unstructured.Unstructured) (string, string) {
+	// OLM scopes the webhooks to the target namespaces of the OperatorGroup
+	namespace := options.namespace
+	if len(options.operatorGroupData.TargetNamespaces) > 0 {
+		namespace = options.operatorGroupData.TargetNamespaces[0]
+	}
+	obj.SetNamespace(namespace)
+
+	err := options.client.CreateUnstructured(ctx, obj)
+	if err == nil {
+		if err := options.client.DeleteUnstructured(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			logger.Errorw("could not delete invalid operand", "error", err, "kind", obj.GetKind(), "name", obj.GetName())
+		}
+		return classifyRejection(nil), ""
+	}
+	return classifyRejection(err), err.Error()
+}
+
+// checkWebhook checks that OLM created a webhook definition of the CSV and that its service has ready endpoints.
+// Validating webhooks also get an invalid operand, which they must reject for the check to pass.
+func checkWebhook(ctx context.Context, options *auditOptions, clientset kubernetes.Interface, desc operatorv1alpha1.WebhookDescription, crds []apiextensionsv1.CustomResourceDefinition) (report.Webhook, error) {
+	webhook := report.Webhook{
+		Name: desc.GenerateName,
+		Type: string(desc.Type),
+	}
+
+	var service *webhookService
+	switch desc.Type {
+	case operatorv1alpha1.ConversionWebhook:
+		webhook.Configurations, webhook.Missing, service = conversionWebhookCRDs(desc, crds)
+		webhook.Created = len(webhook.Missing) == 0
+	default:
+		var err error
+		webhook.Configurations, service, err = admissionWebhookConfigurations(ctx, clientset, options.namespace, desc)
+		if err != nil {
+			return webhook, err
+		}
+		webhook.Created = len(webhook.Configurations) > 0
+	}
+
+	if service != nil {
+		webhook.Service = service.namespace + "/" + service.name
+		ready, err := readyEndpoints(ctx, clientset, *service)
+		if err != nil {
+			return webhook, fmt.Errorf("could not get endpoints of %s: %v", webhook.Service, err)
+		}
+		webhook.ReadyEndpoints = ready
+	}
+
+	if desc.Type == operatorv1alpha1.ValidatingAdmissionWebhook && webhook.Created {
+		if obj, field := invalidOperand(desc, options.customResources, crds); obj != nil {
+			webhook.InvalidOperand = obj.GetKind() + "/" + obj.GetName()
+			webhook.InvalidField = field
+			webhook.Rejection, webhook.RejectionMessage = sendInvalidOperand(ctx, options, obj)
+		}
+	}
+
+	webhook.Passed = webhook.Created && webhook.ReadyEndpoints > 0 && (webhook.InvalidOperand == "" || webhook.Rejection == "rejected by webhook")
+
+	return webhook, nil
+}
+
+// webhooks checks the admission and conversion webhooks declared by the operator's CSV
+func webhooks(ctx context.Context, opts ...auditOption) (auditFn, auditCleanupFn) {
+	options, err := applyOptions(opts)
+	if err != nil {
+		return failed(err), noCleanup
+	}
+
+	return func(ctx context.Context) error {
+		logger.Debugw("checking webhooks for operator", "package", options.subscription.Package, "channel", options.subscription.Channel, "installmode", options.subscription.InstallModeType)
+
+		csv, err := currentCSV(ctx, &options)
+		if err != nil {
+			return err
+		}
+		if len(csv.Spec.WebhookDefinitions) == 0 {
+			logger.Infow("exiting Webhooks since the CSV declares no webhook definitions")
+			return nil
+		}
+
+		crds := &apiextensionsv1.CustomResourceDefinitionList{}
+		if err := options.client.ListCRDs(ctx, crds); err != nil {
+			return fmt.Errorf("could not list CRDs: %v", err)
+		}
+
+		if err := extractAlmExamples(ctx, &options); err != nil {
+			logger.Errorf("could not get ALM Examples: %v", err)
+		}
+
+		clientset, err := k8sClientset()
+		if err != nil {
+			return fmt.Errorf("could not get clientset: %v", err)
+		}
+
+		checked := []report.Webhook{}
+		for _, desc := range csv.Spec.WebhookDefinitions {
+			webhook, err := checkWebhook(ctx, &options, clientset, desc, crds.Items)
+			if err != nil {
+				logger.Errorw("could not check webhook", "error", err, "webhook", desc.GenerateName)
+			}
+			checked = append(checked, webhook)
+		}
+
+		return writeReports(&options, "webhooks", report.TemplateData{
+			Webhooks: checked,
+		}, report.WebhooksJsonReport, report.WebhooksTextReport)
+	}, noCleanup
+}
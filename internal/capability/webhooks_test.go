@@ -0,0 +1,267 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opdev/opcap/internal/operator"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// webhookDeniedClient is an operator.Client behind a validating webhook that denies every object
+type webhookDeniedClient struct {
+	operator.Client
+}
+
+func (c webhookDeniedClient) CreateUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
+	return apierrors.NewForbidden(schema.GroupResource{Group: "example.com", Resource: "testkinds"}, obj.GetName(),
+		fmt.Errorf(`admission webhook "vtestkind.example.com" denied the request: spec.size is required`))
+}
+
+// schemaInvalidClient is an operator.Client whose CRD schema refuses every object before any webhook is called
+type schemaInvalidClient struct {
+	operator.Client
+}
+
+func (c schemaInvalidClient) CreateUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
+	return apierrors.NewInvalid(schema.GroupKind{Group: "example.com", Kind: "TestKind"}, obj.GetName(), nil)
+}
+
+var _ = Describe("Webhooks", func() {
+	var desc operatorv1alpha1.WebhookDescription
+	var crds []apiextensionsv1.CustomResourceDefinition
+	var examples []map[string]interface{}
+
+	BeforeEach(func() {
+		desc = operatorv1alpha1.WebhookDescription{
+			GenerateName: "vtestkind.example.com",
+			Type:         operatorv1alpha1.ValidatingAdmissionWebhook,
+			Rules: []admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"example.com"},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"testkinds"},
+				},
+			}},
+		}
+		crds = []apiextensionsv1.CustomResourceDefinition{{
+			ObjectMeta: metav1.ObjectMeta{Name: "testkinds.example.com"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: "example.com",
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "TestKind", Plural: "testkinds"},
+			},
+		}}
+		examples = []map[string]interface{}{{
+			"apiVersion": "example.com/v1",
+			"kind":       "TestKind",
+			"metadata":   map[string]interface{}{"name": "example"},
+			"spec":       map[string]interface{}{"size": int64(3)},
+		}}
+	})
+
+	When("looking for the webhook configurations created by OLM", func() {
+		It("should find the configuration of the CSV and its service", func() {
+			clientset := fake.NewSimpleClientset(
+				&admissionregistrationv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "vtestkind.example.com-abcde",
+						Labels: map[string]string{webhookDescriptionLabel: desc.GenerateName, olmOwnerNamespaceLabel: "testns"},
+					},
+					Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+						Name: desc.GenerateName,
+						ClientConfig: admissionregistrationv1.WebhookClientConfig{
+							Service: &admissionregistrationv1.ServiceReference{Namespace: "testns", Name: "test-controller-manager-service"},
+						},
+					}},
+				},
+				&admissionregistrationv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "vtestkind.example.com-other",
+						Labels: map[string]string{webhookDescriptionLabel: desc.GenerateName, olmOwnerNamespaceLabel: "otherns"},
+					},
+				},
+			)
+
+			configurations, service, err := admissionWebhookConfigurations(context.TODO(), clientset, "testns", desc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configurations).To(Equal([]string{"vtestkind.example.com-abcde"}))
+			Expect(*service).To(Equal(webhookService{namespace: "testns", name: "test-controller-manager-service"}))
+		})
+		It("should report CRDs not converted through the webhook", func() {
+			desc.Type = operatorv1alpha1.ConversionWebhook
+			desc.ConversionCRDs = []string{"testkinds.example.com", "missings.example.com"}
+			crds[0].Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
+				Webhook: &apiextensionsv1.WebhookConversion{
+					ClientConfig: &apiextensionsv1.WebhookClientConfig{
+						Service: &apiextensionsv1.ServiceReference{Namespace: "testns", Name: "test-controller-manager-service"},
+					},
+				},
+			}
+
+			converted, missing, service := conversionWebhookCRDs(desc, crds)
+			Expect(converted).To(Equal([]string{"testkinds.example.com"}))
+			Expect(missing).To(Equal([]string{"missings.example.com"}))
+			Expect(service.name).To(Equal("test-controller-manager-service"))
+		})
+	})
+	When("counting the endpoints of a webhook service", func() {
+		It("should only count ready addresses", func() {
+			clientset := fake.NewSimpleClientset(&corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-service"},
+				Subsets: []corev1.EndpointSubset{{
+					Addresses:         []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+					NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.2"}},
+				}},
+			})
+
+			ready, err := readyEndpoints(context.TODO(), clientset, webhookService{namespace: "testns", name: "test-service"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ready).To(Equal(1))
+
+			ready, err = readyEndpoints(context.TODO(), clientset, webhookService{namespace: "testns", name: "missing"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ready).To(BeZero())
+		})
+	})
+	When("building an invalid operand", func() {
+		It("should break one field of a copy of an example intercepted by the webhook", func() {
+			examples[0]["spec"] = map[string]interface{}{
+				"size":    int64(3),
+				"storage": map[string]interface{}{"class": "standard"},
+			}
+
+			obj, field := invalidOperand(desc, examples, crds)
+			Expect(obj).ToNot(BeNil())
+			Expect(obj.GetName()).To(Equal("example-opcap-invalid"))
+			Expect(field).To(Equal("spec.size"))
+			Expect(obj.Object["spec"]).To(Equal(map[string]interface{}{
+				"size":    int64(-1),
+				"storage": map[string]interface{}{"class": "standard"},
+			}))
+			Expect(examples[0]["spec"]).To(HaveKeyWithValue("size", int64(3)))
+		})
+		It("should break nested fields in sorted key order", func() {
+			spec := map[string]interface{}{
+				"enabled": true,
+				"config":  map[string]interface{}{"mode": "fast"},
+				"size":    int64(3),
+			}
+			Expect(breakField(spec, []string{"spec"})).To(Equal([]string{"spec", "config", "mode"}))
+			Expect(spec["config"]).To(HaveKeyWithValue("mode", "opcap-invalid"))
+			Expect(spec["size"]).To(Equal(int64(3)))
+		})
+		It("should skip examples without a field to break", func() {
+			examples[0]["spec"] = map[string]interface{}{"enabled": true}
+			obj, _ := invalidOperand(desc, examples, crds)
+			Expect(obj).To(BeNil())
+		})
+		It("should skip examples outside of the webhook rules", func() {
+			desc.Rules[0].Operations = []admissionregistrationv1.OperationType{admissionregistrationv1.Delete}
+			obj, _ := invalidOperand(desc, examples, crds)
+			Expect(obj).To(BeNil())
+		})
+	})
+	When("classifying the admission of an invalid operand", func() {
+		It("should tell the webhook and schema rejections apart", func() {
+			Expect(classifyRejection(nil)).To(Equal("accepted"))
+			Expect(classifyRejection(errors.New(`Internal error occurred: failed calling webhook "vtestkind.example.com": connection refused`))).To(Equal("webhook unreachable"))
+			Expect(classifyRejection(errors.New(`admission webhook "vtestkind.example.com" denied the request: invalid size`))).To(Equal("rejected by webhook"))
+			Expect(classifyRejection(apierrors.NewInvalid(schema.GroupKind{Group: "example.com", Kind: "TestKind"}, "example", nil))).To(Equal("rejected by schema"))
+		})
+	})
+	When("checking a validating webhook", func() {
+		It("should report the webhook rejecting the invalid operand", func() {
+			clientset := fake.NewSimpleClientset(
+				&admissionregistrationv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "vtestkind.example.com-abcde",
+						Labels: map[string]string{webhookDescriptionLabel: desc.GenerateName, olmOwnerNamespaceLabel: "testns"},
+					},
+					Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+						Name: desc.GenerateName,
+						ClientConfig: admissionregistrationv1.WebhookClientConfig{
+							Service: &admissionregistrationv1.ServiceReference{Namespace: "testns", Name: "test-service"},
+						},
+					}},
+				},
+				&corev1.Endpoints{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-service"},
+					Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+				},
+			)
+			options := &auditOptions{
+				client:            webhookDeniedClient{operator.NewFakeOpClient()},
+				namespace:         "testns",
+				operatorGroupData: &operator.OperatorGroupData{TargetNamespaces: []string{"testns-targetns1"}},
+				customResources:   examples,
+			}
+
+			webhook, err := checkWebhook(context.TODO(), options, clientset, desc, crds)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhook.Created).To(BeTrue())
+			Expect(webhook.Service).To(Equal("testns/test-service"))
+			Expect(webhook.ReadyEndpoints).To(Equal(1))
+			Expect(webhook.InvalidOperand).To(Equal("TestKind/example-opcap-invalid"))
+			Expect(webhook.InvalidField).To(Equal("spec.size"))
+			Expect(webhook.Rejection).To(Equal("rejected by webhook"))
+			Expect(webhook.RejectionMessage).To(ContainSubstring("spec.size is required"))
+			Expect(webhook.Passed).To(BeTrue())
+		})
+		It("should fail when the invalid operand is not rejected by the webhook", func() {
+			clientset := fake.NewSimpleClientset(
+				&admissionregistrationv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "vtestkind.example.com-abcde",
+						Labels: map[string]string{webhookDescriptionLabel: desc.GenerateName, olmOwnerNamespaceLabel: "testns"},
+					},
+					Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+						Name: desc.GenerateName,
+						ClientConfig: admissionregistrationv1.WebhookClientConfig{
+							Service: &admissionregistrationv1.ServiceReference{Namespace: "testns", Name: "test-service"},
+						},
+					}},
+				},
+				&corev1.Endpoints{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-service"},
+					Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+				},
+			)
+			options := &auditOptions{
+				client:            schemaInvalidClient{operator.NewFakeOpClient()},
+				namespace:         "testns",
+				operatorGroupData: &operator.OperatorGroupData{TargetNamespaces: []string{"testns-targetns1"}},
+				customResources:   examples,
+			}
+
+			webhook, err := checkWebhook(context.TODO(), options, clientset, desc, crds)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhook.Created).To(BeTrue())
+			Expect(webhook.ReadyEndpoints).To(Equal(1))
+			Expect(webhook.Rejection).To(Equal("rejected by schema"))
+			Expect(webhook.Passed).To(BeFalse())
+		})
+		It("should fail when OLM created no configuration", func() {
+			options := &auditOptions{client: operator.NewFakeOpClient(), namespace: "testns", operatorGroupData: &operator.OperatorGroupData{}}
+
+			webhook, err := checkWebhook(context.TODO(), options, fake.NewSimpleClientset(), desc, crds)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhook.Created).To(BeFalse())
+			Expect(webhook.InvalidOperand).To(BeEmpty())
+			Expect(webhook.Passed).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,112 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	pkgserverv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ChannelEntry is a CSV published in a package channel
+type ChannelEntry struct {
+	Name    string
+	Version string
+}
+
+// ChannelData holds the CSVs published in a package channel
+type ChannelData struct {
+	Name       string
+	CurrentCSV string
+	Entries    []ChannelEntry
+}
+
+// PreviousCSV returns the newest entry in the channel that is older than the channel head
+// or an empty string if the channel only has one version or entries are not available
+func (d ChannelData) PreviousCSV() string {
+	var head semver.Version
+	for _, entry := range d.Entries {
+		if entry.Name == d.CurrentCSV {
+			v, err := semver.ParseTolerant(entry.Version)
+			if err != nil {
+				return ""
+			}
+			head = v
+		}
+	}
+
+	var previous string
+	var previousVersion semver.Version
+	for _, entry := range d.Entries {
+		v, err := semver.ParseTolerant(entry.Version)
+		if err != nil || entry.Name == d.CurrentCSV {
+			continue
+		}
+		if v.LT(head) && (previous == "" || v.GT(previousVersion)) {
+			previous = entry.Name
+			previousVersion = v
+		}
+	}
+
+	return previous
+}
+
+// GetChannelData reads the channel entries from the package manifest of a given catalog source.
+// Entries are read from the unstructured object since they are not part of the typed
+// PackageManifest API vendored here, and older package servers may not serve them at all.
+func (c operatorClient) GetChannelData(ctx context.Context, packageName string, catalogSource string, channel string) (*ChannelData, error) {
+	pkgManifests := &unstructured.UnstructuredList{}
+	pkgManifests.SetGroupVersionKind(pkgserverv1.SchemeGroupVersion.WithKind(pkgserverv1.PackageManifestListKind))
+	if err := c.Client.List(ctx, pkgManifests); err != nil {
+		return nil, fmt.Errorf("could not list PackageManifest objects: %v", err)
+	}
+
+	for _, pkgm := range pkgManifests.Items {
+		if pkgm.GetName() != packageName {
+			continue
+		}
+		source, _, _ := unstructured.NestedString(pkgm.Object, "status", "catalogSource")
+		if catalogSource != "" && source != catalogSource {
+			continue
+		}
+
+		if channelData, ok := channelFromPackageManifest(pkgm, channel); ok {
+			return channelData, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find channel %s for package %s in catalog source %s", channel, packageName, catalogSource)
+}
+
+// channelFromPackageManifest reads a channel head and its entries from an unstructured PackageManifest
+func channelFromPackageManifest(pkgm unstructured.Unstructured, channel string) (*ChannelData, bool) {
+	channels, _, _ := unstructured.NestedSlice(pkgm.Object, "status", "channels")
+	for _, ch := range channels {
+		pkgch, ok := ch.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(pkgch, "name"); name != channel {
+			continue
+		}
+
+		channelData := &ChannelData{Name: channel}
+		channelData.CurrentCSV, _, _ = unstructured.NestedString(pkgch, "currentCSV")
+
+		entries, _, _ := unstructured.NestedSlice(pkgch, "entries")
+		for _, e := range entries {
+			entry, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(entry, "name")
+			version, _, _ := unstructured.NestedString(entry, "version")
+			channelData.Entries = append(channelData.Entries, ChannelEntry{Name: name, Version: version})
+		}
+
+		return channelData, true
+	}
+
+	return nil, false
+}
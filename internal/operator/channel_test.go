@@ -0,0 +1,136 @@
+package operator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pkgserverv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Channel", func() {
+	DescribeTable("PreviousCSV",
+		func(currentCSV string, entries []ChannelEntry, expected string) {
+			channel := ChannelData{Name: "stable", CurrentCSV: currentCSV, Entries: entries}
+			Expect(channel.PreviousCSV()).To(Equal(expected))
+		},
+		Entry("returns the newest entry older than the head", "testoperator.v1.2.0", []ChannelEntry{
+			{Name: "testoperator.v1.0.0", Version: "1.0.0"},
+			{Name: "testoperator.v1.2.0", Version: "1.2.0"},
+			{Name: "testoperator.v1.1.0", Version: "1.1.0"},
+		}, "testoperator.v1.1.0"),
+		Entry("returns nothing for a single-entry channel", "testoperator.v1.2.0", []ChannelEntry{
+			{Name: "testoperator.v1.2.0", Version: "1.2.0"},
+		}, ""),
+		Entry("returns nothing without entries", "testoperator.v1.2.0", nil, ""),
+		Entry("skips entries newer than the head and entries without a semver version", "testoperator.v1.2.0", []ChannelEntry{
+			{Name: "testoperator.v1.3.0", Version: "1.3.0"},
+			{Name: "testoperator.v1.2.0", Version: "1.2.0"},
+			{Name: "testoperator.latest", Version: "latest"},
+			{Name: "testoperator.unversioned"},
+			{Name: "testoperator.v1.1.0", Version: "1.1.0"},
+		}, "testoperator.v1.1.0"),
+		Entry("orders by version rather than by non-semver names", "testoperator-stable", []ChannelEntry{
+			{Name: "testoperator-stable", Version: "2.0.0"},
+			{Name: "testoperator-build-9", Version: "1.9.0"},
+			{Name: "testoperator-build-10", Version: "1.10.0"},
+		}, "testoperator-build-10"),
+		Entry("accepts versions with a v prefix", "testoperator.v1.2.0", []ChannelEntry{
+			{Name: "testoperator.v1.2.0", Version: "v1.2.0"},
+			{Name: "testoperator.v1.1.0", Version: "v1.1.0"},
+		}, "testoperator.v1.1.0"),
+		Entry("returns nothing when the head version is not semver", "testoperator.latest", []ChannelEntry{
+			{Name: "testoperator.latest", Version: "latest"},
+			{Name: "testoperator.v1.1.0", Version: "1.1.0"},
+		}, ""),
+		Entry("returns nothing when the head is not listed", "testoperator.v1.2.0", []ChannelEntry{
+			{Name: "testoperator.v1.1.0", Version: "1.1.0"},
+		}, ""),
+	)
+
+	Context("channelFromPackageManifest", func() {
+		var pkgm unstructured.Unstructured
+
+		BeforeEach(func() {
+			pkgm = unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "packages.operators.coreos.com/v1",
+				"kind":       "PackageManifest",
+				"metadata":   map[string]interface{}{"name": "testoperator"},
+				"status": map[string]interface{}{
+					"channels": []interface{}{
+						map[string]interface{}{"name": "alpha", "currentCSV": "testoperator.v2.0.0-alpha"},
+						map[string]interface{}{
+							"name":       "stable",
+							"currentCSV": "testoperator.v1.2.0",
+							"entries": []interface{}{
+								map[string]interface{}{"name": "testoperator.v1.2.0", "version": "1.2.0"},
+								map[string]interface{}{"name": "testoperator.v1.1.0", "version": "1.1.0"},
+								"invalid",
+							},
+						},
+					},
+				},
+			}}
+		})
+		It("should read the channel head and entries", func() {
+			channel, ok := channelFromPackageManifest(pkgm, "stable")
+			Expect(ok).To(BeTrue())
+			Expect(channel.CurrentCSV).To(Equal("testoperator.v1.2.0"))
+			Expect(channel.Entries).To(Equal([]ChannelEntry{
+				{Name: "testoperator.v1.2.0", Version: "1.2.0"},
+				{Name: "testoperator.v1.1.0", Version: "1.1.0"},
+			}))
+			Expect(channel.PreviousCSV()).To(Equal("testoperator.v1.1.0"))
+		})
+		It("should return no entries when the package server doesn't list them", func() {
+			channel, ok := channelFromPackageManifest(pkgm, "alpha")
+			Expect(ok).To(BeTrue())
+			Expect(channel.CurrentCSV).To(Equal("testoperator.v2.0.0-alpha"))
+			Expect(channel.Entries).To(BeEmpty())
+			Expect(channel.PreviousCSV()).To(BeEmpty())
+		})
+		It("should not find missing channels", func() {
+			_, ok := channelFromPackageManifest(pkgm, "beta")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("GetChannelData", func() {
+		var client Client
+
+		BeforeEach(func() {
+			client = NewFakeOpClient(&pkgserverv1.PackageManifest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testoperator",
+					Namespace: "openshift-marketplace",
+				},
+				Status: pkgserverv1.PackageManifestStatus{
+					CatalogSource: "testcatalog",
+					Channels: []pkgserverv1.PackageChannel{
+						{
+							Name:       "stable",
+							CurrentCSV: "testoperator.v1.2.0",
+						},
+					},
+					DefaultChannel: "stable",
+				},
+			})
+		})
+
+		When("the channel exists", func() {
+			It("should return the channel head", func() {
+				channel, err := client.GetChannelData(context.TODO(), "testoperator", "testcatalog", "stable")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(channel.CurrentCSV).To(Equal("testoperator.v1.2.0"))
+			})
+		})
+		When("the channel does not exist", func() {
+			It("should return an error", func() {
+				_, err := client.GetChannelData(context.TODO(), "testoperator", "testcatalog", "alpha")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -23,20 +26,29 @@ import (
 type Client interface {
 	CreateNamespace(ctx context.Context, name string) (*corev1.Namespace, error)
 	DeleteNamespace(ctx context.Context, name string) error
+	LabelNamespace(ctx context.Context, name string, labels map[string]string) error
 	CreateOperatorGroup(ctx context.Context, data OperatorGroupData, namespace string) (*operatorv1.OperatorGroup, error)
+	CreateScopedServiceAccount(ctx context.Context, data OperatorGroupData, namespace string) error
+	BindClusterRole(ctx context.Context, name string, namespace string, clusterRole string, user string) error
 	DeleteOperatorGroup(ctx context.Context, name string, namespace string) error
 	CreateSubscription(ctx context.Context, data SubscriptionData, namespace string) (*operatorv1alpha1.Subscription, error)
 	DeleteSubscription(ctx context.Context, name string, namespace string) error
 	DeleteCSV(ctx context.Context, name string, namespace string) error
 	GetCompletedCsvWithTimeout(ctx context.Context, namespace string, delay time.Duration) (*operatorv1alpha1.ClusterServiceVersion, error)
+	GetCompletedCsvByNameWithTimeout(ctx context.Context, name string, namespace string, delay time.Duration) (*operatorv1alpha1.ClusterServiceVersion, error)
+	GetInstallPlanWithTimeout(ctx context.Context, csvName string, namespace string, delay time.Duration) (*operatorv1alpha1.InstallPlan, error)
+	ApproveInstallPlan(ctx context.Context, name string, namespace string) error
+	ListInstallPlans(ctx context.Context, namespace string) (*operatorv1alpha1.InstallPlanList, error)
 	GetOpenShiftVersion(ctx context.Context) (string, error)
 	ListPackageManifests(ctx context.Context, list *pkgserverv1.PackageManifestList, catalogSource string, filter []string) error
 	GetSubscriptionData(ctx context.Context, source string, namespace string, filter []string) ([]SubscriptionData, error)
+	GetChannelData(ctx context.Context, packageName string, catalogSource string, channel string) (*ChannelData, error)
 	ListCRDs(ctx context.Context, list *apiextensionsv1.CustomResourceDefinitionList) error
 	CreateUnstructured(ctx context.Context, obj *unstructured.Unstructured) error
 	GetUnstructured(ctx context.Context, namespace, name string, obj *unstructured.Unstructured) error
 	DeleteUnstructured(ctx context.Context, obj *unstructured.Unstructured) error
 	UpdateUnstructured(ctx context.Context, obj *unstructured.Unstructured) error
+	ListUnstructured(ctx context.Context, namespace string, list *unstructured.UnstructuredList) error
 	ListClusterServiceVersions(ctx context.Context, namespace string) (*operatorv1alpha1.ClusterServiceVersionList, error)
 }
 
@@ -65,6 +77,18 @@ func addSchemes(scheme *runtime.Scheme) error {
 		return err
 	}
 
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+
+	if err := rbacv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+
 	if err := configv1.Install(scheme); err != nil {
 		return err
 	}
@@ -90,6 +114,22 @@ func NewOpCapClient(kubeconfig *rest.Config) (Client, error) {
 	return operatorClient, nil
 }
 
+// NewImpersonatingOpCapClient creates a client that acts as user, member of groups, instead of the identity in
+// kubeconfig. The identity in kubeconfig must be allowed to impersonate users and groups.
+func NewImpersonatingOpCapClient(kubeconfig *rest.Config, user string, groups []string) (Client, error) {
+	return NewOpCapClient(impersonatingConfig(kubeconfig, user, groups))
+}
+
+// impersonatingConfig returns a copy of kubeconfig that impersonates user, member of groups
+func impersonatingConfig(kubeconfig *rest.Config, user string, groups []string) *rest.Config {
+	config := rest.CopyConfig(kubeconfig)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: user,
+		Groups:   groups,
+	}
+	return config
+}
+
 // NewDynamicClient creates a new dynamic client or returns an error.
 func newDynamicClient(kubeconfig *rest.Config) (dynamic.Interface, error) {
 	return dynamic.NewForConfig(kubeconfig)
@@ -2,11 +2,14 @@ package operator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -73,6 +76,34 @@ func (c operatorClient) GetCompletedCsvWithTimeout(ctx context.Context, namespac
 	return nil, fmt.Errorf("unexpected error while waiting for csv")
 }
 
+// Gets a completed CSV by name, Succeeded or Failed, with timeout on delay duration
+// Other CSVs on the same namespace, like the one being replaced during an upgrade, are ignored
+func (c operatorClient) GetCompletedCsvByNameWithTimeout(ctx context.Context, name string, namespace string, delay time.Duration) (*operatorv1alpha1.ClusterServiceVersion, error) {
+	csv := &operatorv1alpha1.ClusterServiceVersion{}
+
+	err := wait.PollImmediateWithContext(ctx, time.Second, delay, func(ctx context.Context) (bool, error) {
+		if err := c.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, csv); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return csv.Status.Phase == operatorv1alpha1.CSVPhaseSucceeded ||
+			csv.Status.Phase == operatorv1alpha1.CSVPhaseFailed, nil
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		if csv.Name == "" {
+			return nil, TimeoutError
+		}
+		return csv, TimeoutError
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get csv: %s: %v", name, err)
+	}
+
+	return csv, nil
+}
+
 // waits for CSV on namespace and gets a watcher for CSV events
 func (c operatorClient) csvWatcher(ctx context.Context, namespace string) (watch.Interface, error) {
 	watcher, err := c.Client.Watch(ctx, &operatorv1alpha1.ClusterServiceVersionList{}, &client.ListOptions{Namespace: namespace})
@@ -93,7 +124,7 @@ func (c *operatorClient) DeleteCSV(ctx context.Context, name, namespace string)
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("could not delete csv: %v", err)
+		return fmt.Errorf("could not delete csv: %w", err)
 	}
 
 	// wait for csv to be deleted
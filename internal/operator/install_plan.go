@@ -0,0 +1,69 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opdev/opcap/internal/logger"
+
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetInstallPlanWithTimeout waits for an InstallPlan that installs csvName on namespace, with timeout on delay duration
+func (c operatorClient) GetInstallPlanWithTimeout(ctx context.Context, csvName string, namespace string, delay time.Duration) (*operatorv1alpha1.InstallPlan, error) {
+	var installPlan *operatorv1alpha1.InstallPlan
+
+	err := wait.PollImmediateWithContext(ctx, time.Second, delay, func(ctx context.Context) (bool, error) {
+		installPlans := &operatorv1alpha1.InstallPlanList{}
+		if err := c.Client.List(ctx, installPlans, &client.ListOptions{Namespace: namespace}); err != nil {
+			return false, err
+		}
+		for i, ip := range installPlans.Items {
+			for _, name := range ip.Spec.ClusterServiceVersionNames {
+				if name == csvName {
+					installPlan = &installPlans.Items[i]
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return nil, TimeoutError
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not list installplans: %v", err)
+	}
+
+	return installPlan, nil
+}
+
+// ApproveInstallPlan approves a Manual InstallPlan so OLM can proceed with it
+func (c operatorClient) ApproveInstallPlan(ctx context.Context, name string, namespace string) error {
+	logger.Debugw("approving installplan", "installplan", name, "namespace", namespace)
+	installPlan := &operatorv1alpha1.InstallPlan{}
+	if err := c.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, installPlan); err != nil {
+		return fmt.Errorf("could not get installplan: %s: %v", name, err)
+	}
+
+	installPlan.Spec.Approved = true
+	if err := c.Client.Update(ctx, installPlan); err != nil {
+		return fmt.Errorf("could not approve installplan: %s: %v", name, err)
+	}
+
+	logger.Debugw("installplan approved", "installplan", name, "namespace", namespace)
+	return nil
+}
+
+// ListInstallPlans lists the InstallPlans in namespace
+func (c operatorClient) ListInstallPlans(ctx context.Context, namespace string) (*operatorv1alpha1.InstallPlanList, error) {
+	installPlans := &operatorv1alpha1.InstallPlanList{}
+	if err := c.Client.List(ctx, installPlans, &client.ListOptions{Namespace: namespace}); err != nil {
+		return nil, fmt.Errorf("could not list installplans: %v", err)
+	}
+	return installPlans, nil
+}
@@ -0,0 +1,78 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("InstallPlan", func() {
+	var client Client
+	var installPlan operatorv1alpha1.InstallPlan
+
+	BeforeEach(func() {
+		installPlan = operatorv1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "install-abcde",
+				Namespace: "testns",
+			},
+			Spec: operatorv1alpha1.InstallPlanSpec{
+				ClusterServiceVersionNames: []string{"testoperator.v1.1.0"},
+				Approval:                   operatorv1alpha1.ApprovalManual,
+				Approved:                   false,
+			},
+		}
+		client = NewFakeOpClient(&installPlan)
+	})
+
+	Context("GetInstallPlanWithTimeout", func() {
+		When("an installplan exists for the CSV", func() {
+			It("should return it", func() {
+				ip, err := client.GetInstallPlanWithTimeout(context.TODO(), "testoperator.v1.1.0", "testns", time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ip.ObjectMeta.Name).To(Equal("install-abcde"))
+			})
+		})
+		When("no installplan exists for the CSV", func() {
+			It("should timeout", func() {
+				_, err := client.GetInstallPlanWithTimeout(context.TODO(), "testoperator.v1.2.0", "testns", time.Second)
+				Expect(err).To(Equal(TimeoutError))
+			})
+		})
+	})
+
+	Context("ApproveInstallPlan", func() {
+		When("the installplan exists", func() {
+			It("should be approved", func() {
+				Expect(client.ApproveInstallPlan(context.TODO(), "install-abcde", "testns")).To(Succeed())
+
+				opClient := client.(*operatorClient)
+				approved := operatorv1alpha1.InstallPlan{}
+				Expect(opClient.Client.Get(context.TODO(), runtimeClient.ObjectKey{Name: "install-abcde", Namespace: "testns"}, &approved)).To(Succeed())
+				Expect(approved.Spec.Approved).To(BeTrue())
+			})
+		})
+		When("the installplan does not exist", func() {
+			It("should return an error", func() {
+				Expect(client.ApproveInstallPlan(context.TODO(), "notfound", "testns")).ToNot(Succeed())
+			})
+		})
+	})
+
+	Context("ListInstallPlans", func() {
+		It("should list the installplans in the namespace", func() {
+			installPlans, err := client.ListInstallPlans(context.TODO(), "testns")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(installPlans.Items).To(HaveLen(1))
+
+			installPlans, err = client.ListInstallPlans(context.TODO(), "otherns")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(installPlans.Items).To(BeEmpty())
+		})
+	})
+})
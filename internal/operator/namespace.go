@@ -20,7 +20,7 @@ func (o *operatorClient) CreateNamespace(ctx context.Context, name string) (*cor
 		},
 	}
 	if err := o.Client.Create(ctx, &nsSpec, &runtimeClient.CreateOptions{}); err != nil {
-		return nil, fmt.Errorf("could not create namespace: %s: %v", name, err)
+		return nil, fmt.Errorf("could not create namespace: %s: %w", name, err)
 	}
 	logger.Debugf("Namespace Created: %s", name)
 	return &nsSpec, nil
@@ -35,8 +35,29 @@ func (o *operatorClient) DeleteNamespace(ctx context.Context, name string) error
 		},
 	}
 	if err := o.Client.Delete(ctx, &nsSpec, &runtimeClient.DeleteOptions{}); err != nil {
-		return fmt.Errorf("could not delete namespace: %s: %v", name, err)
+		return fmt.Errorf("could not delete namespace: %s: %w", name, err)
 	}
 	logger.Debugf("Namespace Deleted: %s", name)
 	return nil
 }
+
+// LabelNamespace adds labels to an existing namespace, overwriting the values of labels already set
+func (o *operatorClient) LabelNamespace(ctx context.Context, name string, labels map[string]string) error {
+	logger.Debugw("labeling namespace", "namespace", name, "labels", labels)
+	ns := corev1.Namespace{}
+	if err := o.Client.Get(ctx, runtimeClient.ObjectKey{Name: name}, &ns); err != nil {
+		return fmt.Errorf("could not get namespace: %s: %w", name, err)
+	}
+
+	patch := runtimeClient.MergeFrom(ns.DeepCopy())
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	for key, value := range labels {
+		ns.Labels[key] = value
+	}
+	if err := o.Client.Patch(ctx, &ns, patch); err != nil {
+		return fmt.Errorf("could not label namespace: %s: %w", name, err)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -57,4 +58,24 @@ var _ = Describe("Namespace", func() {
 			})
 		})
 	})
+	Context("LabelNamespace", func() {
+		When("labeling an existing namespace", func() {
+			JustBeforeEach(func() {
+				_, err := operatorClient.CreateNamespace(context.TODO(), "testns")
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should add the labels", func() {
+				Expect(operatorClient.LabelNamespace(context.TODO(), "testns", map[string]string{"testlabel": "testvalue"})).To(Succeed())
+
+				ns := corev1.Namespace{}
+				Expect(operatorClient.Client.Get(context.TODO(), runtimeClient.ObjectKey{Name: "testns"}, &ns)).To(Succeed())
+				Expect(ns.Labels).To(HaveKeyWithValue("testlabel", "testvalue"))
+			})
+		})
+		When("labeling a namespace that does not exist", func() {
+			It("should return error", func() {
+				Expect(operatorClient.LabelNamespace(context.TODO(), "testns", map[string]string{"testlabel": "testvalue"})).ToNot(Succeed())
+			})
+		})
+	})
 })
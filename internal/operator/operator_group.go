@@ -7,12 +7,20 @@ import (
 	"github.com/opdev/opcap/internal/logger"
 
 	operatorv1 "github.com/operator-framework/api/pkg/operators/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type OperatorGroupData struct {
 	Name             string
 	TargetNamespaces []string
+
+	// ServiceAccountName scopes the OperatorGroup to a ServiceAccount OLM uses to install operators
+	// instead of its own cluster-admin privileges
+	ServiceAccountName string
+
+	// ServiceAccountRules are granted to ServiceAccountName in the OperatorGroup's own and target namespaces
+	ServiceAccountRules []rbacv1.PolicyRule
 }
 
 func (o *operatorClient) CreateOperatorGroup(ctx context.Context, data OperatorGroupData, namespace string) (*operatorv1.OperatorGroup, error) {
@@ -23,12 +31,13 @@ func (o *operatorClient) CreateOperatorGroup(ctx context.Context, data OperatorG
 			Namespace: namespace,
 		},
 		Spec: operatorv1.OperatorGroupSpec{
-			TargetNamespaces: data.TargetNamespaces,
+			TargetNamespaces:   data.TargetNamespaces,
+			ServiceAccountName: data.ServiceAccountName,
 		},
 	}
 	err := o.Client.Create(ctx, operatorGroup)
 	if err != nil {
-		return nil, fmt.Errorf("could not create operatorgroup: %s: %v", data.Name, err)
+		return nil, fmt.Errorf("could not create operatorgroup: %s: %w", data.Name, err)
 	}
 
 	logger.Debugw("operatorgroup created", "operatorgroup", data.Name, "namespace", namespace)
@@ -45,7 +54,7 @@ func (o *operatorClient) DeleteOperatorGroup(ctx context.Context, name string, n
 	}
 	err := o.Client.Delete(ctx, &operatorGroup)
 	if err != nil {
-		return fmt.Errorf("could not delete operatorgroup: %s: namespace: %s: %v", name, namespace, err)
+		return fmt.Errorf("could not delete operatorgroup: %s: namespace: %s: %w", name, namespace, err)
 	}
 
 	logger.Debugw("operatorgroup deleted", "operatorgroup", name, "namespace", namespace)
@@ -0,0 +1,38 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opdev/opcap/internal/logger"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BindClusterRole grants a user the permissions of a ClusterRole in namespace through a RoleBinding
+func (o *operatorClient) BindClusterRole(ctx context.Context, name string, namespace string, clusterRole string, user string) error {
+	logger.Debugw("binding clusterrole", "rolebinding", name, "clusterrole", clusterRole, "user", user, "namespace", namespace)
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.UserKind,
+				Name:     user,
+			},
+		},
+	}
+	if err := o.Client.Create(ctx, roleBinding); err != nil {
+		return fmt.Errorf("could not create rolebinding: %s: namespace: %s: %v", name, namespace, err)
+	}
+	return nil
+}
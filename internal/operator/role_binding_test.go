@@ -0,0 +1,53 @@
+package operator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/client-go/rest"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("RoleBinding", func() {
+	var client Client
+
+	BeforeEach(func() {
+		client = NewFakeOpClient()
+	})
+
+	Context("BindClusterRole", func() {
+		When("binding a clusterrole to a user", func() {
+			It("should create a rolebinding in the namespace", func() {
+				Expect(client.BindClusterRole(context.TODO(), "testbinding", "testns", "admin", "testuser")).To(Succeed())
+
+				opClient := client.(*operatorClient)
+				roleBinding := &rbacv1.RoleBinding{}
+				Expect(opClient.Client.Get(context.TODO(), runtimeClient.ObjectKey{Name: "testbinding", Namespace: "testns"}, roleBinding)).To(Succeed())
+				Expect(roleBinding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"}))
+				Expect(roleBinding.Subjects).To(ConsistOf(rbacv1.Subject{APIGroup: "rbac.authorization.k8s.io", Kind: "User", Name: "testuser"}))
+			})
+		})
+		When("the rolebinding already exists", func() {
+			JustBeforeEach(func() {
+				Expect(client.BindClusterRole(context.TODO(), "testbinding", "testns", "admin", "testuser")).To(Succeed())
+			})
+			It("should error", func() {
+				Expect(client.BindClusterRole(context.TODO(), "testbinding", "testns", "admin", "testuser")).ToNot(Succeed())
+			})
+		})
+	})
+
+	Context("impersonatingConfig", func() {
+		It("should impersonate the user without changing the original kubeconfig", func() {
+			kubeconfig := &rest.Config{Host: "https://127.0.0.1:6443", BearerToken: "testtoken"}
+			config := impersonatingConfig(kubeconfig, "testuser", []string{"system:authenticated"})
+			Expect(config.Host).To(Equal(kubeconfig.Host))
+			Expect(config.BearerToken).To(Equal("testtoken"))
+			Expect(config.Impersonate.UserName).To(Equal("testuser"))
+			Expect(config.Impersonate.Groups).To(ConsistOf("system:authenticated"))
+			Expect(kubeconfig.Impersonate.UserName).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opdev/opcap/internal/logger"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateScopedServiceAccount creates the ServiceAccount an OperatorGroup is scoped to in namespace and grants it the
+// OperatorGroup's ServiceAccountRules through a Role and RoleBinding in namespace and in each target namespace
+func (o *operatorClient) CreateScopedServiceAccount(ctx context.Context, data OperatorGroupData, namespace string) error {
+	logger.Debugw("creating scoped serviceaccount", "serviceaccount", data.ServiceAccountName, "namespace", namespace)
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      data.ServiceAccountName,
+			Namespace: namespace,
+		},
+	}
+	if err := o.Client.Create(ctx, serviceAccount); err != nil {
+		return fmt.Errorf("could not create serviceaccount: %s: %v", data.ServiceAccountName, err)
+	}
+
+	namespaces := []string{namespace}
+	for _, ns := range data.TargetNamespaces {
+		if ns != namespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	for _, ns := range namespaces {
+		role := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      data.ServiceAccountName,
+				Namespace: ns,
+			},
+			Rules: data.ServiceAccountRules,
+		}
+		if err := o.Client.Create(ctx, role); err != nil {
+			return fmt.Errorf("could not create role: %s: namespace: %s: %v", role.Name, ns, err)
+		}
+
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      data.ServiceAccountName,
+				Namespace: ns,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     role.Name,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      data.ServiceAccountName,
+					Namespace: namespace,
+				},
+			},
+		}
+		if err := o.Client.Create(ctx, roleBinding); err != nil {
+			return fmt.Errorf("could not create rolebinding: %s: namespace: %s: %v", roleBinding.Name, ns, err)
+		}
+	}
+
+	logger.Debugw("scoped serviceaccount created", "serviceaccount", data.ServiceAccountName, "namespace", namespace)
+	return nil
+}
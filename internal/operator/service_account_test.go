@@ -0,0 +1,58 @@
+package operator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceAccount", func() {
+	var client Client
+	var operatorGroupData OperatorGroupData
+
+	BeforeEach(func() {
+		client = NewFakeOpClient()
+		operatorGroupData = OperatorGroupData{
+			Name:               "testog",
+			TargetNamespaces:   []string{"testns", "testns-targetns1"},
+			ServiceAccountName: "testsa",
+			ServiceAccountRules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			},
+		}
+	})
+
+	Context("CreateScopedServiceAccount", func() {
+		When("creating a scoped service account", func() {
+			It("should bind it to its rules in the own and target namespaces", func() {
+				Expect(client.CreateScopedServiceAccount(context.TODO(), operatorGroupData, "testns")).To(Succeed())
+
+				opClient := client.(*operatorClient)
+				serviceAccount := &corev1.ServiceAccount{}
+				Expect(opClient.Client.Get(context.TODO(), runtimeClient.ObjectKey{Name: "testsa", Namespace: "testns"}, serviceAccount)).To(Succeed())
+
+				for _, ns := range []string{"testns", "testns-targetns1"} {
+					role := &rbacv1.Role{}
+					Expect(opClient.Client.Get(context.TODO(), runtimeClient.ObjectKey{Name: "testsa", Namespace: ns}, role)).To(Succeed())
+					Expect(role.Rules).To(Equal(operatorGroupData.ServiceAccountRules))
+
+					roleBinding := &rbacv1.RoleBinding{}
+					Expect(opClient.Client.Get(context.TODO(), runtimeClient.ObjectKey{Name: "testsa", Namespace: ns}, roleBinding)).To(Succeed())
+					Expect(roleBinding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: "ServiceAccount", Name: "testsa", Namespace: "testns"}))
+				}
+			})
+		})
+		When("the service account already exists", func() {
+			JustBeforeEach(func() {
+				Expect(client.CreateScopedServiceAccount(context.TODO(), operatorGroupData, "testns")).To(Succeed())
+			})
+			It("should error", func() {
+				Expect(client.CreateScopedServiceAccount(context.TODO(), operatorGroupData, "testns")).ToNot(Succeed())
+			})
+		})
+	})
+})
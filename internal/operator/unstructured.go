@@ -23,3 +23,8 @@ func (c operatorClient) UpdateUnstructured(ctx context.Context, obj *unstructure
 func (c operatorClient) DeleteUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
 	return c.Client.Delete(ctx, obj, &client.DeleteOptions{})
 }
+
+// ListUnstructured lists the objects of the kind set on list in a namespace. An empty namespace lists cluster wide.
+func (c operatorClient) ListUnstructured(ctx context.Context, namespace string, list *unstructured.UnstructuredList) error {
+	return c.Client.List(ctx, list, &client.ListOptions{Namespace: namespace})
+}
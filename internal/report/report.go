@@ -13,16 +13,41 @@ import (
 )
 
 type TemplateData struct {
-	OcpVersion      string
-	Subscription    operator.SubscriptionData
-	Csv             *operatorv1alpha1.ClusterServiceVersion
-	CsvTimeout      bool
-	CustomResources []map[string]interface{}
-	OperandCount    int
-	Operands        []unstructured.Unstructured
-	CsvEvents       []Event
-	PodEvents       []Event
-	PodLogs         []PodLog
+	OcpVersion              string
+	Subscription            operator.SubscriptionData
+	Csv                     *operatorv1alpha1.ClusterServiceVersion
+	CsvTimeout              bool
+	CustomResources         []map[string]interface{}
+	OperandCount            int
+	Operands                []unstructured.Unstructured
+	CsvEvents               []Event
+	PodEvents               []Event
+	PodLogs                 []PodLog
+	OperatorUpgrade         OperatorUpgrade
+	OperatorUninstall       OperatorUninstall
+	OperandHealth           []OperandHealth
+	OperandStatus           []OperandStatus
+	OperandReconfiguration  []OperandReconfiguration
+	OperandSelfHealing      []OperandSelfHealing
+	OperandScaling          []OperandScaling
+	PodSecurity             []PodSecurity
+	RestrictedPodSecurity   bool
+	LeastPrivilege          LeastPrivilege
+	ContainerResources      []ContainerResources
+	OperandHighAvailability []OperandHighAvailability
+	OperandDisruption       []OperandDisruption
+	Monitoring              Monitoring
+	MetricsEndpoint         []MetricsEndpoint
+	OperandEvents           []OperandEvents
+	OperandDeletion         []OperandDeletion
+	WatchScope              WatchScope
+	NamespaceAdmin          []NamespaceAdmin
+	CRDQuality              []CRDQuality
+	AlmExampleValidation    []AlmExampleValidation
+	Webhooks                []Webhook
+	CRDConversion           []CRDConversion
+	OperatorRestart         OperatorRestart
+	LeaderElection          []LeaderElection
 }
 
 type Event struct {
@@ -31,6 +56,8 @@ type Event struct {
 	CreationTimestamp metav1.Time
 	Message           string
 	Reason            string
+	Type              string
+	Source            string
 }
 
 type PodLog struct {
@@ -39,6 +66,351 @@ type PodLog struct {
 	PodLogs       string
 }
 
+type OperatorUpgrade struct {
+	FromCSV     string
+	FromVersion string
+	ToCSV       string
+	ToVersion   string
+	Duration    time.Duration
+	Phase       operatorv1alpha1.ClusterServiceVersionPhase
+	Message     string
+	Reason      operatorv1alpha1.ConditionReason
+	Timeout     bool
+	Skipped     string
+}
+
+type OperatorUninstall struct {
+	Leaked       []LeakedResource
+	RetainedCRDs []string
+	Skipped      string
+}
+
+type LeakedResource struct {
+	Kind        string
+	Name        string
+	Terminating bool
+}
+
+type OperandHealth struct {
+	Kind        string
+	Name        string
+	Healthy     bool
+	NoWorkloads bool
+	Resources   []ResourceHealth
+}
+
+type ResourceHealth struct {
+	Kind        string
+	Name        string
+	Ready       bool
+	TimeToReady time.Duration
+}
+
+type OperandStatus struct {
+	Kind               string
+	Name               string
+	Generation         int64
+	ObservedGeneration int64
+	Conditions         []Condition
+	Passed             bool
+}
+
+type Condition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+type OperandReconfiguration struct {
+	Kind               string
+	Name               string
+	Source             string
+	Change             string
+	Generation         int64
+	ObservedGeneration int64
+	ChangedResources   []string
+	Restored           bool
+	Passed             bool
+}
+
+type OperandSelfHealing struct {
+	Kind      string
+	Name      string
+	Healthy   bool
+	Resources []ResourceRecovery
+	Healed    bool
+}
+
+type ResourceRecovery struct {
+	Kind          string
+	Name          string
+	Recovered     bool
+	TimeToRecover time.Duration
+}
+
+type OperandScaling struct {
+	Kind   string
+	Name   string
+	Field  string
+	Steps  []ScalingStep
+	Passed bool
+}
+
+type ScalingStep struct {
+	Replicas  int64
+	Unset     bool
+	Workloads []string
+	Scaled    bool
+	Duration  time.Duration
+}
+
+type PodSecurity struct {
+	Namespace     string
+	Name          string
+	SCC           string
+	RestrictedSCC bool
+	Restricted    bool
+	Containers    []ContainerSecurity
+}
+
+type ContainerSecurity struct {
+	Name                     string
+	RunAsNonRoot             bool
+	Privileged               bool
+	HostNetwork              bool
+	HostPID                  bool
+	HostPathVolumes          []string
+	AllowPrivilegeEscalation bool
+	CapabilitiesAdded        []string
+	SeccompProfile           string
+	Findings                 []string
+}
+
+type ContainerResources struct {
+	Source         string
+	Owner          string
+	Name           string
+	CPURequest     bool
+	MemoryRequest  bool
+	CPULimit       bool
+	MemoryLimit    bool
+	LivenessProbe  bool
+	ReadinessProbe bool
+	Missing        []string
+}
+
+type OperandHighAvailability struct {
+	Kind      string
+	Name      string
+	Workloads []WorkloadAvailability
+}
+
+type WorkloadAvailability struct {
+	Kind                string
+	Name                string
+	Replicas            int32
+	PodDisruptionBudget string
+	AntiAffinity        bool
+	TopologySpread      bool
+	RollingUpdate       bool
+	Findings            []string
+}
+
+type OperandDisruption struct {
+	Kind                string
+	Name                string
+	Ready               bool
+	Evictions           []PodEviction
+	PDBBlocked          bool
+	Recovered           bool
+	AvailabilityDropped bool
+	Unavailable         time.Duration
+}
+
+type PodEviction struct {
+	Pod     string
+	Evicted bool
+	Blocked bool
+}
+
+type Monitoring struct {
+	ServiceMonitors []string
+	PodMonitors     []string
+	PrometheusRules []string
+	Alerts          []AlertRule
+	Passed          bool
+}
+
+type AlertRule struct {
+	PrometheusRule string
+	Group          string
+	Alert          string
+	Severity       string
+	RunbookURL     string
+	Findings       []string
+}
+
+type MetricsEndpoint struct {
+	Service        string
+	Port           string
+	Scheme         string
+	ProxyForbidden bool
+	Reachable      bool
+	RequiresAuth   bool
+	Families       []string
+	Error          string
+}
+
+type OperandEvents struct {
+	Kind           string
+	Name           string
+	Passed         bool
+	OperatorEvents int
+	Events         []Event
+	Types          []EventCount
+	Reasons        []EventCount
+}
+
+type EventCount struct {
+	Name  string
+	Count int
+}
+
+type OperandDeletion struct {
+	Kind                   string
+	Name                   string
+	Deleted                bool
+	TimeToDelete           time.Duration
+	Finalizers             []string
+	ForcedFinalizerRemoval bool
+	Leftovers              []string
+}
+
+type WatchScope struct {
+	TargetNamespaces   []string
+	UnwatchedNamespace string
+	Operands           []ScopedOperand
+	Passed             bool
+}
+
+type ScopedOperand struct {
+	Kind       string
+	Name       string
+	Namespace  string
+	Watched    bool
+	Reconciled bool
+	Error      string
+}
+
+type LeastPrivilege struct {
+	ServiceAccount     string
+	PermissionFailures []string
+}
+
+type NamespaceAdmin struct {
+	Role   string
+	User   string
+	Kinds  []KindAccess
+	Passed bool
+}
+
+type CRDQuality struct {
+	Name               string
+	Kind               string
+	Found              bool
+	Versions           []string
+	StoredVersions     []string
+	DeprecatedVersions []string
+	Conversion         string
+	Structural         bool
+	StatusSubresource  bool
+	Fields             int
+	UndescribedFields  []string
+	Findings           []string
+}
+
+type AlmExampleValidation struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	CRD        string
+	Errors     []string
+}
+
+type Webhook struct {
+	Name             string
+	Type             string
+	Created          bool
+	Configurations   []string
+	Missing          []string
+	Service          string
+	ReadyEndpoints   int
+	InvalidOperand   string
+	InvalidField     string
+	Rejection        string
+	RejectionMessage string
+	Passed           bool
+}
+
+type CRDConversion struct {
+	CRD            string
+	Kind           string
+	StorageVersion string
+	CreatedVersion string
+	Versions       []VersionRoundTrip
+	Error          string
+	Passed         bool
+}
+
+type VersionRoundTrip struct {
+	Version       string
+	Read          bool
+	Written       bool
+	LostFields    []string
+	ChangedFields []string
+	Error         string
+}
+
+type OperatorRestart struct {
+	DeletedPods []string
+	RestartTime time.Duration
+	Restarted   bool
+	Operands    []OperandRestart
+	Passed      bool
+}
+
+type OperandRestart struct {
+	Kind       string
+	Name       string
+	Healthy    bool
+	Changed    []string
+	Recreated  []string
+	Duplicated []string
+	Orphaned   []string
+	Error      string
+}
+
+type LeaderElection struct {
+	Deployment    string
+	Replicas      int32
+	Lock          string
+	LeaderPods    []string
+	HolderChanges []string
+	FailedOver    bool
+	FailoverTime  time.Duration
+	Error         string
+	Passed        bool
+}
+
+type KindAccess struct {
+	Kind   string
+	Create bool
+	Read   bool
+	Delete bool
+	Errors []string
+}
+
 func replace(input, from, to string) string {
 	return strings.Replace(input, from, to, -1)
 }
@@ -94,3 +466,187 @@ func DebugTextReport(w io.Writer, data TemplateData) error {
 func DebugJsonReport(w io.Writer, data TemplateData) error {
 	return processTemplate(w, debugJSONDataTemplate, data)
 }
+
+func OperatorUpgradeTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operatorUpgradeTextReportTemplate, data)
+}
+
+func OperatorUpgradeJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operatorUpgradeJsonReportTemplate, data)
+}
+
+func OperandHealthTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandHealthTextReportTemplate, data)
+}
+
+func OperandHealthJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandHealthJsonReportTemplate, data)
+}
+
+func OperandStatusTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandStatusTextReportTemplate, data)
+}
+
+func OperandStatusJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandStatusJsonReportTemplate, data)
+}
+
+func OperandReconfigurationTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandReconfigurationTextReportTemplate, data)
+}
+
+func OperandReconfigurationJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandReconfigurationJsonReportTemplate, data)
+}
+
+func OperandSelfHealingTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandSelfHealingTextReportTemplate, data)
+}
+
+func OperandSelfHealingJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandSelfHealingJsonReportTemplate, data)
+}
+
+func OperandScalingTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandScalingTextReportTemplate, data)
+}
+
+func OperandScalingJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandScalingJsonReportTemplate, data)
+}
+
+func OperatorUninstallTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operatorUninstallTextReportTemplate, data)
+}
+
+func OperatorUninstallJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operatorUninstallJsonReportTemplate, data)
+}
+
+func PodSecurityTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, podSecurityTextReportTemplate, data)
+}
+
+func PodSecurityJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, podSecurityJsonReportTemplate, data)
+}
+
+func ContainerResourcesTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, containerResourcesTextReportTemplate, data)
+}
+
+func ContainerResourcesJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, containerResourcesJsonReportTemplate, data)
+}
+
+func OperandHighAvailabilityTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandHighAvailabilityTextReportTemplate, data)
+}
+
+func OperandHighAvailabilityJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandHighAvailabilityJsonReportTemplate, data)
+}
+
+func OperandDisruptionTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandDisruptionTextReportTemplate, data)
+}
+
+func OperandDisruptionJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandDisruptionJsonReportTemplate, data)
+}
+
+func MonitoringTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, monitoringTextReportTemplate, data)
+}
+
+func MonitoringJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, monitoringJsonReportTemplate, data)
+}
+
+func MetricsEndpointTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, metricsEndpointTextReportTemplate, data)
+}
+
+func MetricsEndpointJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, metricsEndpointJsonReportTemplate, data)
+}
+
+func OperandEventsTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandEventsTextReportTemplate, data)
+}
+
+func OperandEventsJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandEventsJsonReportTemplate, data)
+}
+
+func OperandDeletionTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandDeletionTextReportTemplate, data)
+}
+
+func OperandDeletionJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operandDeletionJsonReportTemplate, data)
+}
+
+func WatchScopeTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, watchScopeTextReportTemplate, data)
+}
+
+func WatchScopeJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, watchScopeJsonReportTemplate, data)
+}
+
+func NamespaceAdminTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, namespaceAdminTextReportTemplate, data)
+}
+
+func NamespaceAdminJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, namespaceAdminJsonReportTemplate, data)
+}
+
+func CRDQualityTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, crdQualityTextReportTemplate, data)
+}
+
+func CRDQualityJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, crdQualityJsonReportTemplate, data)
+}
+
+func AlmExampleValidationTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, almExampleValidationTextReportTemplate, data)
+}
+
+func AlmExampleValidationJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, almExampleValidationJsonReportTemplate, data)
+}
+
+func WebhooksTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, webhooksTextReportTemplate, data)
+}
+
+func WebhooksJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, webhooksJsonReportTemplate, data)
+}
+
+func CRDConversionTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, crdConversionTextReportTemplate, data)
+}
+
+func CRDConversionJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, crdConversionJsonReportTemplate, data)
+}
+
+func OperatorRestartTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operatorRestartTextReportTemplate, data)
+}
+
+func OperatorRestartJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, operatorRestartJsonReportTemplate, data)
+}
+
+func LeaderElectionTextReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, leaderElectionTextReportTemplate, data)
+}
+
+func LeaderElectionJsonReport(w io.Writer, data TemplateData) error {
+	return processTemplate(w, leaderElectionJsonReportTemplate, data)
+}
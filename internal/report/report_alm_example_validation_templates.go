@@ -0,0 +1,19 @@
+package report
+
+const (
+	almExampleValidationTextReportTemplate = `
+{{ with $dot := . }}
+ALM Example Validation Report
+-----------------------------------------
+Report Date: {{ now }}
+{{ if $dot.OcpVersion }}OpenShift Version: {{ $dot.OcpVersion }}
+{{ end }}Package Name: {{ $dot.Subscription.Package }}
+{{ range .AlmExampleValidation }}{{ .Kind }}/{{ .Name }} ({{ .APIVersion }}): {{ if .Errors }}invalid{{ else }}valid{{ end }}
+{{ range .Errors }}  {{ . }}
+{{ end }}{{ else }}No ALM examples
+{{ end }}-----------------------------------------
+{{ end }}
+`
+
+	almExampleValidationJsonReportTemplate = `{{ with $dot := . }}{{ range .AlmExampleValidation }}{"package":"{{ $dot.Subscription.Package }}","apiVersion":"{{ .APIVersion }}","kind":"{{ .Kind }}","name":"{{ .Name }}","crd":"{{ .CRD }}","message":"{{ if .Errors }}invalid{{ else }}valid{{ end }}","errors":[{{ range $index, $error := .Errors }}{{ if $index }},{{ end }}"{{ replace $error "\"" "" }}"{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
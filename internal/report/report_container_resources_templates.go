@@ -0,0 +1,18 @@
+package report
+
+const (
+	containerResourcesTextReportTemplate = `
+{{ with $dot := . }}
+Container Resources Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+{{ range .ContainerResources }}{{ .Source }} {{ .Owner }} container {{ .Name }}: {{ if .Missing }}missing {{ range $index, $missing := .Missing }}{{ if $index }}, {{ end }}{{ $missing }}{{ end }}{{ else }}complete{{ end }}
+{{ else }}No containers
+{{ end }}-----------------------------------------
+{{ end }}
+`
+
+	containerResourcesJsonReportTemplate = `{{ with $dot := . }}{{ range .ContainerResources }}{"package":"{{ $dot.Subscription.Package }}","source":"{{ .Source }}","owner":"{{ .Owner }}","container":"{{ .Name }}","message":"{{ if .Missing }}incomplete{{ else }}complete{{ end }}","cpuRequest":{{ .CPURequest }},"memoryRequest":{{ .MemoryRequest }},"cpuLimit":{{ .CPULimit }},"memoryLimit":{{ .MemoryLimit }},"livenessProbe":{{ .LivenessProbe }},"readinessProbe":{{ .ReadinessProbe }}}{{"\n"}}{{ end }}{{ end }}`
+)
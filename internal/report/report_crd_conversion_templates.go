@@ -0,0 +1,27 @@
+package report
+
+const (
+	crdConversionTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .CRDConversion }}
+
+CRD Conversion Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+CRD: {{ .CRD }}
+Kind: {{ .Kind }}
+{{ if .Error }}Error: {{ .Error }}
+{{ else }}Storage Version: {{ .StorageVersion }}
+Created Version: {{ .CreatedVersion }}
+Round Trips:
+{{ range .Versions }}  {{ .Version }}: {{ if .Error }}{{ .Error }}{{ else if or .LostFields .ChangedFields }}{{ if .LostFields }}lost {{ range $index, $field := .LostFields }}{{ if $index }}, {{ end }}{{ $field }}{{ end }}{{ end }}{{ if and .LostFields .ChangedFields }}; {{ end }}{{ if .ChangedFields }}changed {{ range $index, $field := .ChangedFields }}{{ if $index }}, {{ end }}{{ $field }}{{ end }}{{ end }}{{ else }}no field lost{{ end }}
+{{ end }}{{ end }}Result: {{ if .Passed }}Passed{{ else }}Failed{{ end }}
+-----------------------------------------
+{{ end }}
+{{ end }}
+`
+
+	crdConversionJsonReportTemplate = `{{ with $dot := . }}{{ range .CRDConversion }}{"package":"{{ $dot.Subscription.Package }}","crd":"{{ .CRD }}","kind":"{{ .Kind }}","message":"{{ if .Passed }}passed{{ else }}failed{{ end }}","storageVersion":"{{ .StorageVersion }}","createdVersion":"{{ .CreatedVersion }}","error":"{{ replace .Error "\"" "" }}","versions":[{{ range $index, $roundTrip := .Versions }}{{ if $index }},{{ end }}{"version":"{{ $roundTrip.Version }}","read":{{ $roundTrip.Read }},"written":{{ $roundTrip.Written }},"lostFields":[{{ range $i, $field := $roundTrip.LostFields }}{{ if $i }},{{ end }}"{{ $field }}"{{ end }}],"changedFields":[{{ range $i, $field := $roundTrip.ChangedFields }}{{ if $i }},{{ end }}"{{ $field }}"{{ end }}],"error":"{{ replace $roundTrip.Error "\"" "" }}"}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
@@ -0,0 +1,19 @@
+package report
+
+const (
+	crdQualityTextReportTemplate = `
+{{ with $dot := . }}
+CRD Quality Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+{{ range .CRDQuality }}{{ .Name }} ({{ .Kind }}): {{ if not .Found }}not found{{ else }}versions {{ range $index, $version := .Versions }}{{ if $index }}, {{ end }}{{ $version }}{{ end }}{{ if .DeprecatedVersions }} (deprecated {{ range $index, $version := .DeprecatedVersions }}{{ if $index }}, {{ end }}{{ $version }}{{ end }}){{ end }}, conversion {{ .Conversion }}{{ end }}
+{{ range .Findings }}  {{ . }}
+{{ end }}{{ else }}No owned CRDs
+{{ end }}-----------------------------------------
+{{ end }}
+`
+
+	crdQualityJsonReportTemplate = `{{ with $dot := . }}{{ range .CRDQuality }}{"package":"{{ $dot.Subscription.Package }}","crd":"{{ .Name }}","kind":"{{ .Kind }}","message":"{{ if .Findings }}failed{{ else }}passed{{ end }}","found":{{ .Found }},"versions":[{{ range $index, $version := .Versions }}{{ if $index }},{{ end }}"{{ $version }}"{{ end }}],"storedVersions":[{{ range $index, $version := .StoredVersions }}{{ if $index }},{{ end }}"{{ $version }}"{{ end }}],"deprecatedVersions":[{{ range $index, $version := .DeprecatedVersions }}{{ if $index }},{{ end }}"{{ $version }}"{{ end }}],"conversion":"{{ .Conversion }}","structural":{{ .Structural }},"statusSubresource":{{ .StatusSubresource }},"fields":{{ .Fields }},"undescribedFields":[{{ range $index, $field := .UndescribedFields }}{{ if $index }},{{ end }}"{{ $field }}"{{ end }}],"findings":[{{ range $index, $finding := .Findings }}{{ if $index }},{{ end }}"{{ replace $finding "\"" "" }}"{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
@@ -0,0 +1,27 @@
+package report
+
+const (
+	leaderElectionTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .LeaderElection }}
+
+Leader Election Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Deployment: {{ .Deployment }}
+Replicas: {{ .Replicas }}
+{{ if .Error }}Error: {{ .Error }}
+{{ else }}Lock: {{ .Lock }}
+Leader Pods: {{ range $index, $pod := .LeaderPods }}{{ if $index }}, {{ end }}{{ $pod }}{{ end }}
+Holder Identities: {{ range $index, $holder := .HolderChanges }}{{ if $index }} -> {{ end }}{{ $holder }}{{ end }}
+Failover: {{ if .FailedOver }}yes, in {{ .FailoverTime }}{{ else }}no{{ end }}
+{{ end }}Result: {{ if .Passed }}Passed{{ else }}Failed{{ end }}
+-----------------------------------------
+{{ end }}
+{{ end }}
+`
+
+	leaderElectionJsonReportTemplate = `{{ with $dot := . }}{{ range .LeaderElection }}{"package":"{{ $dot.Subscription.Package }}","deployment":"{{ .Deployment }}","message":"{{ if .Passed }}passed{{ else }}failed{{ end }}","replicas":{{ .Replicas }},"lock":"{{ .Lock }}","leaderPods":[{{ range $index, $pod := .LeaderPods }}{{ if $index }},{{ end }}"{{ $pod }}"{{ end }}],"holderChanges":[{{ range $index, $holder := .HolderChanges }}{{ if $index }},{{ end }}"{{ $holder }}"{{ end }}],"failedOver":{{ .FailedOver }},"failoverTime":"{{ .FailoverTime }}","error":"{{ replace .Error "\"" "" }}"}{{"\n"}}{{ end }}{{ end }}`
+)
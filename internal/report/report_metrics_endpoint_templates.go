@@ -0,0 +1,27 @@
+package report
+
+const (
+	metricsEndpointTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .MetricsEndpoint }}
+
+Metrics Endpoint Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Service: {{ .Service }}
+Port: {{ .Port }} ({{ .Scheme }})
+Message: {{ if .ProxyForbidden }}not scraped, the audit user may not get services/proxy{{ else if not .Reachable }}unreachable, {{ .Error }}{{ else if .RequiresAuth }}requires authentication{{ else if .Error }}{{ .Error }}{{ else }}{{ len .Families }} metric families exposed{{ end }}
+Metric Families:
+{{ range .Families }}  {{ . }}
+{{ else }}  none
+{{ end }}-----------------------------------------
+{{ else }}
+No metrics endpoints
+{{ end }}
+{{ end }}
+`
+
+	metricsEndpointJsonReportTemplate = `{{ with $dot := . }}{{ range .MetricsEndpoint }}{"package":"{{ $dot.Subscription.Package }}","service":"{{ .Service }}","port":"{{ .Port }}","scheme":"{{ .Scheme }}","proxyForbidden":{{ .ProxyForbidden }},"reachable":{{ .Reachable }},"requiresAuth":{{ .RequiresAuth }},"error":"{{ replace .Error "\"" "" }}","families":[{{ range $index, $family := .Families }}{{ if $index }},{{ end }}"{{ $family }}"{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
@@ -0,0 +1,23 @@
+package report
+
+const (
+	monitoringTextReportTemplate = `
+Monitoring Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ .OcpVersion }}
+Package Name: {{ .Subscription.Package }}
+Channel: {{ .Subscription.Channel }}
+Catalog Source: {{ .Subscription.CatalogSource }}
+Install Mode: {{ .Subscription.InstallModeType }}
+ServiceMonitors: {{ range $index, $monitor := .Monitoring.ServiceMonitors }}{{ if $index }}, {{ end }}{{ $monitor }}{{ else }}none{{ end }}
+PodMonitors: {{ range $index, $monitor := .Monitoring.PodMonitors }}{{ if $index }}, {{ end }}{{ $monitor }}{{ else }}none{{ end }}
+PrometheusRules: {{ range $index, $rule := .Monitoring.PrometheusRules }}{{ if $index }}, {{ end }}{{ $rule }}{{ else }}none{{ end }}
+Alerts:
+{{ range .Monitoring.Alerts }}  {{ .PrometheusRule }} {{ .Group }}/{{ .Alert }}: {{ if .Findings }}{{ range $index, $finding := .Findings }}{{ if $index }}, {{ end }}{{ $finding }}{{ end }}{{ else }}severity {{ .Severity }}, runbook {{ .RunbookURL }}{{ end }}
+{{ else }}  none
+{{ end }}Result: {{ if .Monitoring.Passed }}Passed{{ else }}Failed{{ end }}
+-----------------------------------------
+`
+	monitoringJsonReportTemplate = `{"level":"info","message":"{{ if .Monitoring.Passed }}passed{{ else }}failed{{ end }}","package":"{{ .Subscription.Package }}","channel":"{{ .Subscription.Channel }}","installmode":"{{ .Subscription.InstallModeType }}","serviceMonitors":[{{ range $index, $monitor := .Monitoring.ServiceMonitors }}{{ if $index }},{{ end }}"{{ $monitor }}"{{ end }}],"podMonitors":[{{ range $index, $monitor := .Monitoring.PodMonitors }}{{ if $index }},{{ end }}"{{ $monitor }}"{{ end }}],"prometheusRules":[{{ range $index, $rule := .Monitoring.PrometheusRules }}{{ if $index }},{{ end }}"{{ $rule }}"{{ end }}],"alerts":[{{ range $index, $alert := .Monitoring.Alerts }}{{ if $index }},{{ end }}{"prometheusRule":"{{ $alert.PrometheusRule }}","group":"{{ $alert.Group }}","alert":"{{ $alert.Alert }}","severity":"{{ $alert.Severity }}","runbookURL":"{{ $alert.RunbookURL }}","findings":[{{ range $i, $finding := $alert.Findings }}{{ if $i }},{{ end }}"{{ $finding }}"{{ end }}]}{{ end }}]}{{"\n"}}`
+)
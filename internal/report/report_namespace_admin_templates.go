@@ -0,0 +1,26 @@
+package report
+
+const (
+	namespaceAdminTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .NamespaceAdmin }}
+
+Namespace Admin Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Role: {{ .Role }}
+User: {{ .User }}
+Kinds:
+{{ range .Kinds }}  {{ .Kind }}: create {{ if .Create }}allowed{{ else }}denied{{ end }}, read {{ if .Read }}allowed{{ else }}denied{{ end }}, delete {{ if .Delete }}allowed{{ else }}denied{{ end }}
+{{ range .Errors }}    {{ . }}
+{{ end }}{{ else }}  none
+{{ end }}Result: {{ if .Passed }}Passed{{ else }}Failed{{ end }}
+-----------------------------------------
+{{ end }}
+{{ end }}
+`
+
+	namespaceAdminJsonReportTemplate = `{{ with $dot := . }}{{ range .NamespaceAdmin }}{"package":"{{ $dot.Subscription.Package }}","message":"{{ if .Passed }}passed{{ else }}failed{{ end }}","role":"{{ .Role }}","user":"{{ .User }}","kinds":[{{ range $index, $kind := .Kinds }}{{ if $index }},{{ end }}{"kind":"{{ $kind.Kind }}","create":{{ $kind.Create }},"read":{{ $kind.Read }},"delete":{{ $kind.Delete }},"errors":[{{ range $i, $error := $kind.Errors }}{{ if $i }},{{ end }}"{{ replace $error "\"" "" }}"{{ end }}]}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
@@ -0,0 +1,29 @@
+package report
+
+const (
+	operandDeletionTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandDeletion }}
+
+Operand Deletion Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+Message: {{ if and .Deleted (not .Leftovers) }}passed{{ else }}failed{{ end }}
+Deletion: {{ if .Deleted }}deleted after {{ .TimeToDelete }}{{ else }}never finished terminating{{ end }}
+{{ if .ForcedFinalizerRemoval }}Finalizers Force Removed: {{ range $index, $finalizer := .Finalizers }}{{ if $index }}, {{ end }}{{ $finalizer }}{{ else }}none{{ end }}
+{{ end }}Resources Left Behind:
+{{ range .Leftovers }}  {{ . }}
+{{ else }}  none
+{{ end }}-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandDeletionJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandDeletion }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","message":"{{ if and .Deleted (not .Leftovers) }}passed{{ else }}failed{{ end }}","deleted":{{ .Deleted }},"timeToDelete":"{{ .TimeToDelete }}","forcedFinalizerRemoval":{{ .ForcedFinalizerRemoval }},"finalizers":[{{ range $index, $finalizer := .Finalizers }}{{ if $index }},{{ end }}"{{ $finalizer }}"{{ end }}],"leftovers":[{{ range $index, $leftover := .Leftovers }}{{ if $index }},{{ end }}"{{ $leftover }}"{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
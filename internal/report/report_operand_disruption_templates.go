@@ -0,0 +1,29 @@
+package report
+
+const (
+	operandDisruptionTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandDisruption }}
+
+Operand Disruption Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+Message: {{ if not .Ready }}skipped, operand not ready before disruption{{ else if .AvailabilityDropped }}failed, operand unavailable for {{ .Unavailable }}{{ else }}passed, operand stayed ready{{ end }}
+{{ if .Ready }}PodDisruptionBudget: {{ if .PDBBlocked }}blocked the drain{{ else }}never blocked the drain{{ end }}
+Recovered: {{ if .Recovered }}yes{{ else }}no{{ end }}
+{{ end }}Evictions:
+{{ range .Evictions }}  Pod/{{ .Pod }}: {{ if .Blocked }}blocked by PodDisruptionBudget{{ else if .Evicted }}evicted{{ else }}not evicted{{ end }}
+{{ else }}  none
+{{ end }}-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandDisruptionJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandDisruption }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","message":"{{ if not .Ready }}skipped{{ else if .AvailabilityDropped }}failed{{ else }}passed{{ end }}","pdbBlocked":{{ .PDBBlocked }},"recovered":{{ .Recovered }},"availabilityDropped":{{ .AvailabilityDropped }},"unavailable":"{{ .Unavailable }}","evictions":[{{ range $index, $eviction := .Evictions }}{{ if $index }},{{ end }}{"pod":"{{ $eviction.Pod }}","evicted":{{ $eviction.Evicted }},"blocked":{{ $eviction.Blocked }}}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
@@ -0,0 +1,29 @@
+package report
+
+const (
+	operandEventsTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandEvents }}
+
+Operand Events Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+Message: {{ if .Passed }}passed, {{ .OperatorEvents }} of {{ len .Events }} events emitted by the operator{{ else }}failed, no events emitted by the operator{{ end }}
+Types: {{ range $index, $type := .Types }}{{ if $index }}, {{ end }}{{ $type.Name }} ({{ $type.Count }}){{ else }}none{{ end }}
+Reasons: {{ range $index, $reason := .Reasons }}{{ if $index }}, {{ end }}{{ $reason.Name }} ({{ $reason.Count }}){{ else }}none{{ end }}
+Events:
+{{ range .Events }}  {{ .CreationTimestamp }} {{ .Type }} {{ .Reason }} ({{ .Source }}): {{ .Message }}
+{{ else }}  none
+{{ end }}-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandEventsJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandEvents }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","message":"{{ if .Passed }}passed{{ else }}failed{{ end }}","operatorEvents":{{ .OperatorEvents }},"types":{ {{- range $index, $type := .Types }}{{ if $index }},{{ end }}"{{ $type.Name }}":{{ $type.Count }}{{ end -}} },"reasons":{ {{- range $index, $reason := .Reasons }}{{ if $index }},{{ end }}"{{ $reason.Name }}":{{ $reason.Count }}{{ end -}} },"events":[{{ range $index, $event := .Events }}{{ if $index }},{{ end }}{"type":"{{ $event.Type }}","reason":"{{ $event.Reason }}","message":"{{ $event.Message }}","source":"{{ $event.Source }}","creationTimestamp":"{{ $event.CreationTimestamp }}"}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
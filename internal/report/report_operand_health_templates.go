@@ -0,0 +1,27 @@
+package report
+
+const (
+	operandHealthTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandHealth }}
+
+Operand Health Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+Operand Health: {{ if .NoWorkloads }}No owned workloads{{ else if .Healthy }}Healthy{{ else }}Unhealthy{{ end }}
+Owned Resources:
+{{ range .Resources }}  {{ .Kind }}/{{ .Name }}: {{ if .Ready }}ready after {{ .TimeToReady }}{{ else }}not ready{{ end }}
+{{ else }}  none found
+{{ end }}-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandHealthJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandHealth }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","message":"{{ if .NoWorkloads }}no owned workloads{{ else if .Healthy }}healthy{{ else }}unhealthy{{ end }}","resources":[{{ range $index, $resource := .Resources }}{{ if $index }},{{ end }}{"kind":"{{ $resource.Kind }}","name":"{{ $resource.Name }}","ready":{{ $resource.Ready }},"timeToReady":"{{ $resource.TimeToReady }}"}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
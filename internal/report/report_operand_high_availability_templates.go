@@ -0,0 +1,26 @@
+package report
+
+const (
+	operandHighAvailabilityTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandHighAvailability }}
+
+Operand High Availability Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+Owned Workloads:
+{{ range .Workloads }}  {{ .Kind }}/{{ .Name }} ({{ .Replicas }} replicas): {{ if le .Replicas 1 }}single replica, skipped{{ else if .Findings }}{{ range $index, $finding := .Findings }}{{ if $index }}, {{ end }}{{ $finding }}{{ end }}{{ else }}highly available{{ end }}
+{{ else }}  none found
+{{ end }}-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandHighAvailabilityJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandHighAvailability }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","workloads":[{{ range $index, $workload := .Workloads }}{{ if $index }},{{ end }}{"kind":"{{ $workload.Kind }}","name":"{{ $workload.Name }}","replicas":{{ $workload.Replicas }},"podDisruptionBudget":"{{ $workload.PodDisruptionBudget }}","antiAffinity":{{ $workload.AntiAffinity }},"topologySpread":{{ $workload.TopologySpread }},"rollingUpdate":{{ $workload.RollingUpdate }},"findings":[{{ range $i, $finding := $workload.Findings }}{{ if $i }},{{ end }}"{{ $finding }}"{{ end }}]}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
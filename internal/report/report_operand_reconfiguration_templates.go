@@ -0,0 +1,32 @@
+package report
+
+const (
+	operandReconfigurationTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandReconfiguration }}
+
+Operand Reconfiguration Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+{{ if .Source }}Change: {{ .Change }} ({{ .Source }})
+Generation: {{ .Generation }}
+Observed Generation: {{ .ObservedGeneration }}
+Changed Resources:
+{{ range .ChangedResources }}  {{ . }}
+{{ else }}  none
+{{ end }}Restored: {{ if .Restored }}yes{{ else }}no{{ end }}
+Operand Reconfiguration: {{ if .Passed }}Passed{{ else }}Failed{{ end }}
+{{ else }}Operand Reconfiguration: Skipped, no change available
+{{ end }}-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandReconfigurationJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandReconfiguration }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","message":"{{ if not .Source }}skipped{{ else if .Passed }}passed{{ else }}failed{{ end }}","source":"{{ .Source }}","change":"{{ .Change }}","generation":{{ .Generation }},"observedGeneration":{{ .ObservedGeneration }},"changedResources":[{{ range $index, $resource := .ChangedResources }}{{ if $index }},{{ end }}"{{ $resource }}"{{ end }}],"restored":{{ .Restored }}}{{"\n"}}{{ end }}{{ end }}`
+)
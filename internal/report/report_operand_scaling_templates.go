@@ -0,0 +1,28 @@
+package report
+
+const (
+	operandScalingTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandScaling }}
+
+Operand Scaling Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+{{ if .Field }}podCount Field: {{ .Field }}
+Scaling Steps:
+{{ range .Steps }}  {{ if .Unset }}field removed{{ else }}{{ .Replicas }} replicas{{ end }}: {{ if .Scaled }}{{ range $index, $workload := .Workloads }}{{ if $index }}, {{ end }}{{ $workload }}{{ end }} scaled after {{ .Duration }}{{ else }}no workload scaled after {{ .Duration }}{{ end }}
+{{ end }}Operand Scaling: {{ if .Passed }}Passed{{ else }}Failed{{ end }}
+{{ else }}Operand Scaling: Skipped, no podCount spec descriptor
+{{ end }}-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandScalingJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandScaling }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","message":"{{ if not .Field }}skipped{{ else if .Passed }}passed{{ else }}failed{{ end }}","field":"{{ .Field }}","steps":[{{ range $index, $step := .Steps }}{{ if $index }},{{ end }}{"replicas":{{ $step.Replicas }},"unset":{{ $step.Unset }},"scaled":{{ $step.Scaled }},"duration":"{{ $step.Duration }}","workloads":[{{ range $i, $workload := $step.Workloads }}{{ if $i }},{{ end }}"{{ $workload }}"{{ end }}]}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
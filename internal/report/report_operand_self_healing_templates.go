@@ -0,0 +1,28 @@
+package report
+
+const (
+	operandSelfHealingTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandSelfHealing }}
+
+Operand Self Healing Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+{{ if .Healthy }}Deleted Resources:
+{{ range .Resources }}  {{ .Kind }}/{{ .Name }}: {{ if .Recovered }}recovered after {{ .TimeToRecover }}{{ else }}not recovered{{ end }}
+{{ else }}  none found
+{{ end }}Operand Self Healing: {{ if .Healed }}Passed{{ else }}Failed{{ end }}
+{{ else }}Operand Self Healing: Skipped, operand never became healthy
+{{ end }}-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandSelfHealingJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandSelfHealing }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","message":"{{ if not .Healthy }}skipped{{ else if .Healed }}passed{{ else }}failed{{ end }}","resources":[{{ range $index, $resource := .Resources }}{{ if $index }},{{ end }}{"kind":"{{ $resource.Kind }}","name":"{{ $resource.Name }}","recovered":{{ $resource.Recovered }},"timeToRecover":"{{ $resource.TimeToRecover }}"}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
@@ -0,0 +1,29 @@
+package report
+
+const (
+	operandStatusTextReportTemplate = `
+{{ with $dot := . }}
+{{ range .OperandStatus }}
+
+Operand Status Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+Operand Kind: {{ .Kind }}
+Operand Name: {{ .Name }}
+Generation: {{ .Generation }}
+Observed Generation: {{ .ObservedGeneration }}
+Conditions:
+{{ range .Conditions }}  {{ .Type }}={{ .Status }} {{ .Reason }} {{ .Message }}
+{{ else }}  none found
+{{ end }}Operand Status: {{ if .Passed }}Passed{{ else }}Failed{{ end }}
+-----------------------------------------
+{{ else }}
+No operands
+{{ end }}
+{{ end }}
+`
+
+	operandStatusJsonReportTemplate = `{{ with $dot := . }}{{ range .OperandStatus }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ .Kind }}","Operand Name":"{{ .Name }}","message":"{{ if .Passed }}passed{{ else }}failed{{ end }}","generation":{{ .Generation }},"observedGeneration":{{ .ObservedGeneration }},"conditions":[{{ range $index, $condition := .Conditions }}{{ if $index }},{{ end }}{"type":"{{ $condition.Type }}","status":"{{ $condition.Status }}","reason":"{{ $condition.Reason }}","message":"{{ replace $condition.Message "\"" "" }}"}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
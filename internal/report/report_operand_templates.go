@@ -12,7 +12,8 @@ OpenShift Version: {{ $dot.OcpVersion }}
 Package Name: {{ $dot.Subscription.Package }}
 Operand Kind: {{ kind $value }}
 Operand Name: {{ name $value }}
-Operand Creation: {{ if gt $dot.OperandCount 0 }}Succeeded{{ else }}Failed{{ end }}
+{{ if $dot.RestrictedPodSecurity }}Pod Security Admission: restricted
+{{ end }}Operand Creation: {{ if gt $dot.OperandCount 0 }}Succeeded{{ else }}Failed{{ end }}
 -----------------------------------------
 {{ else }}
 No custom resources
@@ -20,5 +21,5 @@ No custom resources
 {{ end }}
 `
 
-	operandJsonReportTemplate = `{{with $dot := .}}{{range $index, $value := .CustomResources }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ kind $value }}","Operand Name":"{{ name $value }}","message":"{{ if gt $dot.OperandCount 0 }}created{{ else }}failed{{ end }}"}{{ end }}{{ end }}`
+	operandJsonReportTemplate = `{{with $dot := .}}{{range $index, $value := .CustomResources }}{"package":"{{ $dot.Subscription.Package }}","Operand Kind":"{{ kind $value }}","Operand Name":"{{ name $value }}","message":"{{ if gt $dot.OperandCount 0 }}created{{ else }}failed{{ end }}"{{ if $dot.RestrictedPodSecurity }},"podSecurityAdmission":"restricted"{{ end }}}{{ end }}{{ end }}`
 )
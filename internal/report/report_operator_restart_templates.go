@@ -0,0 +1,26 @@
+package report
+
+const (
+	operatorRestartTextReportTemplate = `
+Operator Restart Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ .OcpVersion }}
+Package Name: {{ .Subscription.Package }}
+Channel: {{ .Subscription.Channel }}
+Catalog Source: {{ .Subscription.CatalogSource }}
+Install Mode: {{ .Subscription.InstallModeType }}
+Deleted Pods: {{ range $index, $pod := .OperatorRestart.DeletedPods }}{{ if $index }}, {{ end }}{{ $pod }}{{ end }}
+Restarted: {{ if .OperatorRestart.Restarted }}yes, in {{ .OperatorRestart.RestartTime }}{{ else }}no{{ end }}
+Operands:
+{{ range .OperatorRestart.Operands }}  {{ .Kind }}/{{ .Name }}: {{ if .Error }}{{ .Error }}{{ else if .Healthy }}healthy{{ else }}not healthy{{ end }}
+{{ range .Changed }}    changed: {{ . }}
+{{ end }}{{ range .Recreated }}    recreated: {{ . }}
+{{ end }}{{ range .Duplicated }}    duplicated: {{ . }}
+{{ end }}{{ range .Orphaned }}    orphaned: {{ . }}
+{{ end }}{{ else }}  none
+{{ end }}Result: {{ if .OperatorRestart.Passed }}Passed{{ else }}Failed{{ end }}
+-----------------------------------------
+`
+	operatorRestartJsonReportTemplate = `{"level":"info","message":"{{ if .OperatorRestart.Passed }}passed{{ else }}failed{{ end }}","package":"{{ .Subscription.Package }}","channel":"{{ .Subscription.Channel }}","installmode":"{{ .Subscription.InstallModeType }}","deletedPods":[{{ range $index, $pod := .OperatorRestart.DeletedPods }}{{ if $index }},{{ end }}"{{ $pod }}"{{ end }}],"restarted":{{ .OperatorRestart.Restarted }},"restartTime":"{{ .OperatorRestart.RestartTime }}","operands":[{{ range $index, $operand := .OperatorRestart.Operands }}{{ if $index }},{{ end }}{"kind":"{{ $operand.Kind }}","name":"{{ $operand.Name }}","healthy":{{ $operand.Healthy }},"changed":[{{ range $i, $key := $operand.Changed }}{{ if $i }},{{ end }}"{{ $key }}"{{ end }}],"recreated":[{{ range $i, $key := $operand.Recreated }}{{ if $i }},{{ end }}"{{ $key }}"{{ end }}],"duplicated":[{{ range $i, $key := $operand.Duplicated }}{{ if $i }},{{ end }}"{{ $key }}"{{ end }}],"orphaned":[{{ range $i, $key := $operand.Orphaned }}{{ if $i }},{{ end }}"{{ $key }}"{{ end }}],"error":"{{ replace $operand.Error "\"" "" }}"}{{ end }}]}{{"\n"}}`
+)
@@ -10,11 +10,16 @@ Package Name: {{ .Subscription.Package }}
 Channel: {{ .Subscription.Channel }}
 Catalog Source: {{ .Subscription.CatalogSource }}
 Install Mode: {{ .Subscription.InstallModeType }}
-Result: {{ if .CsvTimeout }}timeout{{ else }}{{ .Csv.Status.Phase }}
+{{ if .RestrictedPodSecurity }}Pod Security Admission: restricted
+{{ end }}{{ if .LeastPrivilege.ServiceAccount }}Service Account: {{ .LeastPrivilege.ServiceAccount }}
+Permission Failures:
+{{ range .LeastPrivilege.PermissionFailures }}  {{ . }}
+{{ else }}  none
+{{ end }}{{ end }}Result: {{ if .CsvTimeout }}timeout{{ else }}{{ .Csv.Status.Phase }}
 Message: {{ .Csv.Status.Message }}
 Reason: {{ .Csv.Status.Reason }}
 {{ end }}
 -----------------------------------------
 `
-	operatorJsonReportTemplate = `{"level":"info","message":"{{ if .CsvTimeout }}timeout{{ else }}{{ .Csv.Status.Phase }}{{ end }}","package":"{{ .Subscription.Package }}","channel":"{{ .Subscription.Channel }}","installmode":"{{ .Subscription.InstallModeType }}"}{{"\n"}}`
+	operatorJsonReportTemplate = `{"level":"info","message":"{{ if .CsvTimeout }}timeout{{ else }}{{ .Csv.Status.Phase }}{{ end }}","package":"{{ .Subscription.Package }}","channel":"{{ .Subscription.Channel }}","installmode":"{{ .Subscription.InstallModeType }}"{{ if .RestrictedPodSecurity }},"podSecurityAdmission":"restricted"{{ end }}{{ if .LeastPrivilege.ServiceAccount }},"serviceAccount":"{{ .LeastPrivilege.ServiceAccount }}","permissionFailures":[{{ range $index, $failure := .LeastPrivilege.PermissionFailures }}{{ if $index }},{{ end }}"{{ $failure }}"{{ end }}]{{ end }}}{{"\n"}}`
 )
@@ -0,0 +1,23 @@
+package report
+
+const (
+	operatorUninstallTextReportTemplate = `
+Operator Uninstall Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ .OcpVersion }}
+Package Name: {{ .Subscription.Package }}
+Channel: {{ .Subscription.Channel }}
+Catalog Source: {{ .Subscription.CatalogSource }}
+Install Mode: {{ .Subscription.InstallModeType }}
+{{ if .OperatorUninstall.Skipped }}Result: Skipped, {{ .OperatorUninstall.Skipped }}
+{{ else }}Leaked Resources:
+{{ range .OperatorUninstall.Leaked }}  {{ .Kind }}/{{ .Name }}{{ if .Terminating }} (terminating){{ end }}
+{{ else }}  none
+{{ end }}{{ if .OperatorUninstall.RetainedCRDs }}Retained CRDs (kept by OLM, not counted as leaks):
+{{ range .OperatorUninstall.RetainedCRDs }}  {{ . }}
+{{ end }}{{ end }}Result: {{ if .OperatorUninstall.Leaked }}Failed{{ else }}Passed{{ end }}
+{{ end }}-----------------------------------------
+`
+	operatorUninstallJsonReportTemplate = `{"level":"info","message":"{{ if .OperatorUninstall.Skipped }}skipped{{ else if .OperatorUninstall.Leaked }}failed{{ else }}passed{{ end }}","package":"{{ .Subscription.Package }}","channel":"{{ .Subscription.Channel }}","installmode":"{{ .Subscription.InstallModeType }}","leaked":[{{ range $index, $leaked := .OperatorUninstall.Leaked }}{{ if $index }},{{ end }}{"kind":"{{ $leaked.Kind }}","name":"{{ $leaked.Name }}","terminating":{{ $leaked.Terminating }}}{{ end }}],"retainedCRDs":[{{ range $index, $crd := .OperatorUninstall.RetainedCRDs }}{{ if $index }},{{ end }}"{{ $crd }}"{{ end }}],"skipped":"{{ .OperatorUninstall.Skipped }}"}{{"\n"}}`
+)
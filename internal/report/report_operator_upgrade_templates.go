@@ -0,0 +1,29 @@
+package report
+
+const (
+	operatorUpgradeTextReportTemplate = `
+Operator Upgrade Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ .OcpVersion }}
+Package Name: {{ .Subscription.Package }}
+Channel: {{ .Subscription.Channel }}
+Catalog Source: {{ .Subscription.CatalogSource }}
+Install Mode: {{ .Subscription.InstallModeType }}
+{{ if .RestrictedPodSecurity }}Pod Security Admission: restricted
+{{ end }}{{ if .LeastPrivilege.ServiceAccount }}Service Account: {{ .LeastPrivilege.ServiceAccount }}
+Permission Failures:
+{{ range .LeastPrivilege.PermissionFailures }}  {{ . }}
+{{ else }}  none
+{{ end }}{{ end }}{{ if .OperatorUpgrade.Skipped }}Upgrade: skipped, {{ .OperatorUpgrade.Skipped }}
+Installed: {{ .OperatorUpgrade.ToCSV }} ({{ .OperatorUpgrade.ToVersion }})
+{{ else }}From: {{ .OperatorUpgrade.FromCSV }} ({{ .OperatorUpgrade.FromVersion }})
+To: {{ .OperatorUpgrade.ToCSV }} ({{ .OperatorUpgrade.ToVersion }})
+Duration: {{ .OperatorUpgrade.Duration }}
+{{ end }}Result: {{ if .OperatorUpgrade.Timeout }}timeout{{ else }}{{ .OperatorUpgrade.Phase }}{{ end }}
+{{ if .OperatorUpgrade.Message }}Message: {{ .OperatorUpgrade.Message }}
+Reason: {{ .OperatorUpgrade.Reason }}
+{{ end }}-----------------------------------------
+`
+	operatorUpgradeJsonReportTemplate = `{"level":"info","message":"{{ if .OperatorUpgrade.Timeout }}timeout{{ else }}{{ .OperatorUpgrade.Phase }}{{ end }}","package":"{{ .Subscription.Package }}","channel":"{{ .Subscription.Channel }}","installmode":"{{ .Subscription.InstallModeType }}","fromVersion":"{{ .OperatorUpgrade.FromVersion }}","toVersion":"{{ .OperatorUpgrade.ToVersion }}","duration":"{{ .OperatorUpgrade.Duration }}","skipped":"{{ .OperatorUpgrade.Skipped }}"{{ if .RestrictedPodSecurity }},"podSecurityAdmission":"restricted"{{ end }}{{ if .LeastPrivilege.ServiceAccount }},"serviceAccount":"{{ .LeastPrivilege.ServiceAccount }}","permissionFailures":[{{ range $index, $failure := .LeastPrivilege.PermissionFailures }}{{ if $index }},{{ end }}"{{ $failure }}"{{ end }}]{{ end }}{{ if .OperatorUpgrade.Message }},"reason":"{{ .OperatorUpgrade.Reason }}","csvMessage":"{{ replace .OperatorUpgrade.Message "\"" "" }}"{{ end }}}{{"\n"}}`
+)
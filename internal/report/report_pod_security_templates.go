@@ -0,0 +1,23 @@
+package report
+
+const (
+	podSecurityTextReportTemplate = `
+{{ with $dot := . }}
+Pod Security Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+{{ range .PodSecurity }}
+Pod: {{ .Namespace }}/{{ .Name }}
+SCC: {{ if .SCC }}{{ .SCC }}{{ else }}none{{ end }}{{ if not .RestrictedSCC }} (elevated){{ end }}
+{{ range .Containers }}  Container {{ .Name }}: {{ range $index, $finding := .Findings }}{{ if $index }}, {{ end }}{{ $finding }}{{ else }}no findings{{ end }}
+{{ end }}Pod Security: {{ if .Restricted }}Restricted{{ else }}Elevated{{ end }}
+{{ else }}
+No pods
+{{ end }}-----------------------------------------
+{{ end }}
+`
+
+	podSecurityJsonReportTemplate = `{{ with $dot := . }}{{ range .PodSecurity }}{"package":"{{ $dot.Subscription.Package }}","namespace":"{{ .Namespace }}","pod":"{{ .Name }}","message":"{{ if .Restricted }}restricted{{ else }}elevated{{ end }}","scc":"{{ .SCC }}","restrictedSCC":{{ .RestrictedSCC }},"containers":[{{ range $index, $container := .Containers }}{{ if $index }},{{ end }}{"name":"{{ $container.Name }}","runAsNonRoot":{{ $container.RunAsNonRoot }},"privileged":{{ $container.Privileged }},"hostNetwork":{{ $container.HostNetwork }},"hostPID":{{ $container.HostPID }},"hostPathVolumes":[{{ range $i, $volume := $container.HostPathVolumes }}{{ if $i }},{{ end }}"{{ $volume }}"{{ end }}],"allowPrivilegeEscalation":{{ $container.AllowPrivilegeEscalation }},"capabilitiesAdded":[{{ range $i, $capability := $container.CapabilitiesAdded }}{{ if $i }},{{ end }}"{{ $capability }}"{{ end }}],"seccompProfile":"{{ $container.SeccompProfile }}","findings":[{{ range $i, $finding := $container.Findings }}{{ if $i }},{{ end }}"{{ $finding }}"{{ end }}]}{{ end }}]}{{"\n"}}{{ end }}{{ end }}`
+)
@@ -3,6 +3,7 @@ package report
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -31,13 +32,13 @@ var _ = Describe("Report", func() {
 		BeforeEach(func() {
 			DeferCleanup(w.Reset)
 			data = TemplateData{
-				OcpVersion: "",
+				OcpVersion: "4.11",
 				Subscription: operator.SubscriptionData{
 					Name:            "testsub",
 					Channel:         "test",
 					CatalogSource:   "testcatalog",
 					Package:         "testpackage",
-					InstallModeType: "v1alpha1.InstallModeTypeAllNamespaces",
+					InstallModeType: v1alpha1.InstallModeTypeAllNamespaces,
 				},
 				Csv: &v1alpha1.ClusterServiceVersion{
 					Status: v1alpha1.ClusterServiceVersionStatus{
@@ -111,6 +112,834 @@ var _ = Describe("Report", func() {
 				})
 			})
 		})
+		Context("Restricted pod security install reports", func() {
+			BeforeEach(func() {
+				data.RestrictedPodSecurity = true
+			})
+			It("should mention the enforced profile", func() {
+				Expect(OperatorInstallTextReport(&w, data)).To(Succeed())
+				Expect(w.String()).To(ContainSubstring("Pod Security Admission: restricted"))
+				w.Reset()
+				Expect(OperatorInstallJsonReport(&w, data)).To(Succeed())
+				Expect(w.String()).To(ContainSubstring(`"podSecurityAdmission":"restricted"`))
+			})
+		})
+		Context("Least privilege install reports", func() {
+			BeforeEach(func() {
+				data.LeastPrivilege = LeastPrivilege{
+					ServiceAccount:     "opcap-installer",
+					PermissionFailures: []string{"clusterroles.rbac.authorization.k8s.io is forbidden"},
+				}
+			})
+			It("should list the permission failures", func() {
+				Expect(OperatorInstallTextReport(&w, data)).To(Succeed())
+				Expect(w.String()).To(ContainSubstring("Service Account: opcap-installer"))
+				Expect(w.String()).To(ContainSubstring("  clusterroles.rbac.authorization.k8s.io is forbidden\n"))
+				w.Reset()
+				Expect(OperatorInstallJsonReport(&w, data)).To(Succeed())
+				Expect(w.String()).To(ContainSubstring(`"serviceAccount":"opcap-installer","permissionFailures":["clusterroles.rbac.authorization.k8s.io is forbidden"]`))
+			})
+		})
+		Context("Operator uninstall reports", func() {
+			BeforeEach(func() {
+				data.OperatorUninstall = OperatorUninstall{
+					Leaked: []LeakedResource{
+						{Kind: "ClusterRole", Name: "testrole", Terminating: true},
+						{Kind: "ValidatingWebhookConfiguration", Name: "testwebhook"},
+					},
+					RetainedCRDs: []string{"testkinds.test.opcap.io"},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperatorUninstallJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"level":"info","message":"failed","package":"testpackage","channel":"test","installmode":"AllNamespaces","leaked":[{"kind":"ClusterRole","name":"testrole","terminating":true},{"kind":"ValidatingWebhookConfiguration","name":"testwebhook","terminating":false}],"retainedCRDs":["testkinds.test.opcap.io"],"skipped":""}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperatorUninstallTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("ValidatingWebhookConfiguration/testwebhook\n"))
+					Expect(w.String()).To(ContainSubstring("ClusterRole/testrole (terminating)"))
+					Expect(w.String()).To(ContainSubstring("Retained CRDs (kept by OLM, not counted as leaks):\n  testkinds.test.opcap.io\n"))
+					Expect(w.String()).To(ContainSubstring("Result: %s", "Failed"))
+				})
+				When("nothing was left behind", func() {
+					BeforeEach(func() {
+						data.OperatorUninstall = OperatorUninstall{}
+					})
+					It("should pass", func() {
+						Expect(OperatorUninstallTextReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(ContainSubstring("Result: %s", "Passed"))
+					})
+				})
+				When("the snapshot was taken after the install", func() {
+					BeforeEach(func() {
+						data.OperatorUninstall = OperatorUninstall{Skipped: "operator installed before the snapshot was taken"}
+					})
+					It("should be skipped", func() {
+						Expect(OperatorUninstallTextReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(ContainSubstring("Result: Skipped, operator installed before the snapshot was taken"))
+						Expect(w.String()).ToNot(ContainSubstring("Leaked Resources:"))
+					})
+					It("should create a valid JSON report", func() {
+						Expect(OperatorUninstallJsonReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(MatchJSON(`{"level":"info","message":"skipped","package":"testpackage","channel":"test","installmode":"AllNamespaces","leaked":[],"retainedCRDs":[],"skipped":"operator installed before the snapshot was taken"}`))
+					})
+				})
+			})
+		})
+		Context("Operator upgrade reports", func() {
+			BeforeEach(func() {
+				data.OperatorUpgrade = OperatorUpgrade{
+					FromCSV:     "testoperator.v1.1.0",
+					FromVersion: "1.1.0",
+					ToCSV:       "testoperator.v1.2.0",
+					ToVersion:   "1.2.0",
+					Duration:    time.Minute,
+					Phase:       v1alpha1.CSVPhaseSucceeded,
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperatorUpgradeJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"level":"info","message":"Succeeded","package":"testpackage","channel":"test","installmode":"AllNamespaces","fromVersion":"1.1.0","toVersion":"1.2.0","duration":"1m0s","skipped":""}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperatorUpgradeTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("From: %s", "testoperator.v1.1.0 (1.1.0)"))
+					Expect(w.String()).To(ContainSubstring("To: %s", "testoperator.v1.2.0 (1.2.0)"))
+					Expect(w.String()).To(ContainSubstring("Result: %s", "Succeeded"))
+				})
+				When("given a timeout", func() {
+					BeforeEach(func() {
+						data.OperatorUpgrade.Timeout = true
+					})
+					It("should report a timeout", func() {
+						Expect(OperatorUpgradeTextReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(ContainSubstring("Result: %s", "timeout"))
+					})
+				})
+				When("given a skipped upgrade", func() {
+					BeforeEach(func() {
+						data.OperatorUpgrade = OperatorUpgrade{
+							ToCSV:     "testoperator.v1.2.0",
+							ToVersion: "1.2.0",
+							Phase:     v1alpha1.CSVPhaseSucceeded,
+							Skipped:   "no previous CSV in channel",
+						}
+					})
+					It("should report the installed channel head", func() {
+						Expect(OperatorUpgradeTextReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(ContainSubstring("Upgrade: skipped, no previous CSV in channel"))
+						Expect(w.String()).To(ContainSubstring("Installed: testoperator.v1.2.0 (1.2.0)"))
+						Expect(w.String()).ToNot(ContainSubstring("From:"))
+					})
+				})
+				When("given an install with least privilege", func() {
+					BeforeEach(func() {
+						data.OperatorUpgrade.Timeout = true
+						data.LeastPrivilege = LeastPrivilege{
+							ServiceAccount:     "opcap-installer",
+							PermissionFailures: []string{"cannot create clusterroles"},
+						}
+					})
+					It("should report the permission failures", func() {
+						Expect(OperatorUpgradeTextReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(ContainSubstring("Service Account: opcap-installer"))
+						Expect(w.String()).To(ContainSubstring("  cannot create clusterroles"))
+						Expect(w.String()).To(ContainSubstring("Result: %s", "timeout"))
+					})
+					It("should create a valid JSON report", func() {
+						Expect(OperatorUpgradeJsonReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(MatchJSON(`{"level":"info","message":"timeout","package":"testpackage","channel":"test","installmode":"AllNamespaces","fromVersion":"1.1.0","toVersion":"1.2.0","duration":"1m0s","skipped":"","serviceAccount":"opcap-installer","permissionFailures":["cannot create clusterroles"]}`))
+					})
+				})
+				When("given a failed install under the restricted pod security profile", func() {
+					BeforeEach(func() {
+						data.RestrictedPodSecurity = true
+						data.OperatorUpgrade.Phase = v1alpha1.CSVPhaseFailed
+						data.OperatorUpgrade.Message = "pods violate PodSecurity"
+						data.OperatorUpgrade.Reason = v1alpha1.CSVReasonComponentFailed
+					})
+					It("should report the CSV status", func() {
+						Expect(OperatorUpgradeTextReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(ContainSubstring("Pod Security Admission: restricted"))
+						Expect(w.String()).To(ContainSubstring("Result: %s", "Failed"))
+						Expect(w.String()).To(ContainSubstring("Message: pods violate PodSecurity"))
+					})
+					It("should create a valid JSON report", func() {
+						Expect(OperatorUpgradeJsonReport(&w, data)).To(Succeed())
+						Expect(w.String()).To(MatchJSON(`{"level":"info","message":"Failed","package":"testpackage","channel":"test","installmode":"AllNamespaces","fromVersion":"1.1.0","toVersion":"1.2.0","duration":"1m0s","skipped":"","podSecurityAdmission":"restricted","reason":"InstallComponentFailed","csvMessage":"pods violate PodSecurity"}`))
+					})
+				})
+			})
+		})
+		Context("Operand health reports", func() {
+			BeforeEach(func() {
+				data.OperandHealth = []OperandHealth{
+					{
+						Kind:    "testkind",
+						Name:    "testname",
+						Healthy: true,
+						Resources: []ResourceHealth{
+							{Kind: "Deployment", Name: "testdeployment", Ready: true, TimeToReady: 5 * time.Second},
+							{Kind: "Pod", Name: "testpod", Ready: true, TimeToReady: 10 * time.Second},
+						},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandHealthJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"healthy","resources":[{"kind":"Deployment","name":"testdeployment","ready":true,"timeToReady":"5s"},{"kind":"Pod","name":"testpod","ready":true,"timeToReady":"10s"}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandHealthTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Operand Health: %s", "Healthy"))
+					Expect(w.String()).To(ContainSubstring("Deployment/testdeployment: ready after 5s"))
+				})
+			})
+			When("given an operand without owned workloads", func() {
+				BeforeEach(func() {
+					data.OperandHealth = []OperandHealth{{Kind: "testkind", Name: "testname", NoWorkloads: true}}
+				})
+				It("should report it separately", func() {
+					Expect(OperandHealthTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Operand Health: %s", "No owned workloads"))
+				})
+				It("should create a valid JSON report", func() {
+					Expect(OperandHealthJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"no owned workloads","resources":[]}`))
+				})
+			})
+		})
+		Context("Operand status reports", func() {
+			BeforeEach(func() {
+				data.OperandStatus = []OperandStatus{
+					{
+						Kind:               "testkind",
+						Name:               "testname",
+						Generation:         2,
+						ObservedGeneration: 2,
+						Conditions: []Condition{
+							{Type: "Ready", Status: "True", Reason: "Reconciled", Message: "all \"good\""},
+						},
+						Passed: true,
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandStatusJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"passed","generation":2,"observedGeneration":2,"conditions":[{"type":"Ready","status":"True","reason":"Reconciled","message":"all good"}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandStatusTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Ready=True Reconciled"))
+					Expect(w.String()).To(ContainSubstring("Operand Status: %s", "Passed"))
+				})
+			})
+		})
+		Context("Operand reconfiguration reports", func() {
+			BeforeEach(func() {
+				data.OperandReconfiguration = []OperandReconfiguration{
+					{
+						Kind:               "testkind",
+						Name:               "testname",
+						Source:             "descriptor",
+						Change:             "spec.size: 1 -> 2",
+						Generation:         2,
+						ObservedGeneration: 2,
+						ChangedResources:   []string{"Deployment/testdeployment (updated)"},
+						Restored:           true,
+						Passed:             true,
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandReconfigurationJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"passed","source":"descriptor","change":"spec.size: 1 -> 2","generation":2,"observedGeneration":2,"changedResources":["Deployment/testdeployment (updated)"],"restored":true}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandReconfigurationTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Change: spec.size: 1 -> 2 (descriptor)"))
+					Expect(w.String()).To(ContainSubstring("Restored: yes"))
+					Expect(w.String()).To(ContainSubstring("Operand Reconfiguration: %s", "Passed"))
+				})
+			})
+		})
+		Context("Operand self healing reports", func() {
+			BeforeEach(func() {
+				data.OperandSelfHealing = []OperandSelfHealing{
+					{
+						Kind:    "testkind",
+						Name:    "testname",
+						Healthy: true,
+						Resources: []ResourceRecovery{
+							{Kind: "Deployment", Name: "testdeployment", Recovered: true, TimeToRecover: 10 * time.Second},
+							{Kind: "Service", Name: "testservice"},
+						},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandSelfHealingJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"failed","resources":[{"kind":"Deployment","name":"testdeployment","recovered":true,"timeToRecover":"10s"},{"kind":"Service","name":"testservice","recovered":false,"timeToRecover":"0s"}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandSelfHealingTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Deployment/testdeployment: recovered after 10s"))
+					Expect(w.String()).To(ContainSubstring("Service/testservice: not recovered"))
+					Expect(w.String()).To(ContainSubstring("Operand Self Healing: %s", "Failed"))
+				})
+			})
+		})
+		Context("Operand scaling reports", func() {
+			BeforeEach(func() {
+				data.OperandScaling = []OperandScaling{
+					{
+						Kind:  "testkind",
+						Name:  "testname",
+						Field: "spec.size",
+						Steps: []ScalingStep{
+							{Replicas: 2, Workloads: []string{"Deployment/testdeployment"}, Scaled: true, Duration: 20 * time.Second},
+							{Unset: true, Workloads: []string{"Deployment/testdeployment"}, Scaled: true, Duration: 5 * time.Second},
+						},
+						Passed: true,
+					},
+					{
+						Kind: "otherkind",
+						Name: "othername",
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandScalingJsonReport(&w, data)).To(Succeed())
+					lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+					Expect(lines).To(HaveLen(2))
+					Expect(lines[0]).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"passed","field":"spec.size","steps":[{"replicas":2,"unset":false,"scaled":true,"duration":"20s","workloads":["Deployment/testdeployment"]},{"replicas":0,"unset":true,"scaled":true,"duration":"5s","workloads":["Deployment/testdeployment"]}]}`))
+					Expect(lines[1]).To(MatchJSON(`{"package":"testpackage","Operand Kind":"otherkind","Operand Name":"othername","message":"skipped","field":"","steps":[]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandScalingTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("2 replicas: Deployment/testdeployment scaled after 20s"))
+					Expect(w.String()).To(ContainSubstring("field removed: Deployment/testdeployment scaled after 5s"))
+					Expect(w.String()).To(ContainSubstring("Operand Scaling: %s", "Passed"))
+					Expect(w.String()).To(ContainSubstring("Operand Scaling: Skipped, no podCount spec descriptor"))
+				})
+			})
+		})
+		Context("Pod security reports", func() {
+			BeforeEach(func() {
+				data.PodSecurity = []PodSecurity{
+					{
+						Namespace:     "testns",
+						Name:          "testpod",
+						SCC:           "privileged",
+						RestrictedSCC: false,
+						Restricted:    false,
+						Containers: []ContainerSecurity{
+							{
+								Name:                     "manager",
+								Privileged:               true,
+								HostPathVolumes:          []string{"host"},
+								AllowPrivilegeEscalation: true,
+								CapabilitiesAdded:        []string{"SYS_ADMIN"},
+								Findings:                 []string{"privileged", "capability added SYS_ADMIN"},
+							},
+						},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(PodSecurityJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","namespace":"testns","pod":"testpod","message":"elevated","scc":"privileged","restrictedSCC":false,"containers":[{"name":"manager","runAsNonRoot":false,"privileged":true,"hostNetwork":false,"hostPID":false,"hostPathVolumes":["host"],"allowPrivilegeEscalation":true,"capabilitiesAdded":["SYS_ADMIN"],"seccompProfile":"","findings":["privileged","capability added SYS_ADMIN"]}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(PodSecurityTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("SCC: privileged (elevated)"))
+					Expect(w.String()).To(ContainSubstring("Container manager: privileged, capability added SYS_ADMIN"))
+					Expect(w.String()).To(ContainSubstring("Pod Security: %s", "Elevated"))
+				})
+			})
+		})
+		Context("Container resources reports", func() {
+			BeforeEach(func() {
+				data.ContainerResources = []ContainerResources{
+					{
+						Source:         "operator",
+						Owner:          "Deployment/testoperator",
+						Name:           "manager",
+						CPURequest:     true,
+						MemoryRequest:  true,
+						CPULimit:       true,
+						MemoryLimit:    true,
+						LivenessProbe:  true,
+						ReadinessProbe: true,
+					},
+					{
+						Source:        "operand",
+						Owner:         "testkind/testname/Pod/testpod",
+						Name:          "app",
+						MemoryRequest: true,
+						Missing:       []string{"cpu request", "liveness probe"},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(ContainerResourcesJsonReport(&w, data)).To(Succeed())
+					lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+					Expect(lines).To(HaveLen(2))
+					Expect(lines[1]).To(MatchJSON(`{"package":"testpackage","source":"operand","owner":"testkind/testname/Pod/testpod","container":"app","message":"incomplete","cpuRequest":false,"memoryRequest":true,"cpuLimit":false,"memoryLimit":false,"livenessProbe":false,"readinessProbe":false}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(ContainerResourcesTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("operator Deployment/testoperator container manager: complete"))
+					Expect(w.String()).To(ContainSubstring("operand testkind/testname/Pod/testpod container app: missing cpu request, liveness probe"))
+				})
+			})
+		})
+		Context("Operand high availability reports", func() {
+			BeforeEach(func() {
+				data.OperandHighAvailability = []OperandHighAvailability{
+					{
+						Kind: "testkind",
+						Name: "testname",
+						Workloads: []WorkloadAvailability{
+							{Kind: "Deployment", Name: "single", Replicas: 1, RollingUpdate: true},
+							{Kind: "StatefulSet", Name: "replicated", Replicas: 3, AntiAffinity: true, RollingUpdate: true, Findings: []string{"no PodDisruptionBudget"}},
+						},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandHighAvailabilityJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","workloads":[{"kind":"Deployment","name":"single","replicas":1,"podDisruptionBudget":"","antiAffinity":false,"topologySpread":false,"rollingUpdate":true,"findings":[]},{"kind":"StatefulSet","name":"replicated","replicas":3,"podDisruptionBudget":"","antiAffinity":true,"topologySpread":false,"rollingUpdate":true,"findings":["no PodDisruptionBudget"]}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandHighAvailabilityTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Deployment/single (1 replicas): single replica, skipped"))
+					Expect(w.String()).To(ContainSubstring("StatefulSet/replicated (3 replicas): no PodDisruptionBudget"))
+				})
+			})
+		})
+		Context("CRD conversion reports", func() {
+			BeforeEach(func() {
+				data.CRDConversion = []CRDConversion{{
+					CRD:            "testkinds.example.com",
+					Kind:           "TestKind",
+					StorageVersion: "v1",
+					CreatedVersion: "v1",
+					Versions: []VersionRoundTrip{
+						{Version: "v1alpha1", Read: true, Written: true, LostFields: []string{"spec.size"}},
+						{Version: "v2", Error: `could not read as v2: conversion webhook for "TestKind" failed`},
+					},
+				}}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(CRDConversionJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","crd":"testkinds.example.com","kind":"TestKind","message":"failed","storageVersion":"v1","createdVersion":"v1","error":"","versions":[{"version":"v1alpha1","read":true,"written":true,"lostFields":["spec.size"],"changedFields":[],"error":""},{"version":"v2","read":false,"written":false,"lostFields":[],"changedFields":[],"error":"could not read as v2: conversion webhook for TestKind failed"}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(CRDConversionTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("v1alpha1: lost spec.size"))
+					Expect(w.String()).To(ContainSubstring(`v2: could not read as v2: conversion webhook for "TestKind" failed`))
+					Expect(w.String()).To(ContainSubstring("Result: %s", "Failed"))
+				})
+			})
+		})
+		Context("Leader election reports", func() {
+			BeforeEach(func() {
+				data.LeaderElection = []LeaderElection{
+					{
+						Deployment:    "test-controller-manager",
+						Replicas:      1,
+						Lock:          "Lease/test.example.com",
+						LeaderPods:    []string{"test-controller-manager-abcde"},
+						HolderChanges: []string{"test-controller-manager-abcde_1234", "test-controller-manager-fghij_5678"},
+						FailedOver:    true,
+						FailoverTime:  16 * time.Second,
+						Passed:        true,
+					},
+					{Deployment: "test-webhook", Replicas: 1, Error: "no leader election lock is held by the operator pods"},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(LeaderElectionJsonReport(&w, data)).To(Succeed())
+					lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+					Expect(lines).To(HaveLen(2))
+					Expect(lines[0]).To(MatchJSON(`{"package":"testpackage","deployment":"test-controller-manager","message":"passed","replicas":1,"lock":"Lease/test.example.com","leaderPods":["test-controller-manager-abcde"],"holderChanges":["test-controller-manager-abcde_1234","test-controller-manager-fghij_5678"],"failedOver":true,"failoverTime":"16s","error":""}`))
+					Expect(lines[1]).To(MatchJSON(`{"package":"testpackage","deployment":"test-webhook","message":"failed","replicas":1,"lock":"","leaderPods":[],"holderChanges":[],"failedOver":false,"failoverTime":"0s","error":"no leader election lock is held by the operator pods"}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(LeaderElectionTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Holder Identities: test-controller-manager-abcde_1234 -> test-controller-manager-fghij_5678"))
+					Expect(w.String()).To(ContainSubstring("Failover: yes, in 16s"))
+					Expect(w.String()).To(ContainSubstring("Error: no leader election lock is held by the operator pods"))
+				})
+			})
+		})
+		Context("Operator restart reports", func() {
+			BeforeEach(func() {
+				data.OperatorRestart = OperatorRestart{
+					DeletedPods: []string{"test-controller-manager-abcde"},
+					RestartTime: 12 * time.Second,
+					Restarted:   true,
+					Operands: []OperandRestart{
+						{Kind: "testkind", Name: "testname", Healthy: true, Changed: []string{"ConfigMap/config (created)", "ConfigMap/config (deleted)"}, Recreated: []string{"ConfigMap/config (1234 -> 5678)"}, Duplicated: []string{"Service/testname-2"}},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperatorRestartJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"level":"info","message":"failed","package":"testpackage","channel":"test","installmode":"AllNamespaces","deletedPods":["test-controller-manager-abcde"],"restarted":true,"restartTime":"12s","operands":[{"kind":"testkind","name":"testname","healthy":true,"changed":["ConfigMap/config (created)","ConfigMap/config (deleted)"],"recreated":["ConfigMap/config (1234 -> 5678)"],"duplicated":["Service/testname-2"],"orphaned":[],"error":""}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperatorRestartTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Restarted: yes, in 12s"))
+					Expect(w.String()).To(ContainSubstring("testkind/testname: healthy\n    changed: ConfigMap/config (created)\n    changed: ConfigMap/config (deleted)\n    recreated: ConfigMap/config (1234 -> 5678)\n    duplicated: Service/testname-2"))
+					Expect(w.String()).To(ContainSubstring("Result: %s", "Failed"))
+				})
+			})
+		})
+		Context("Webhooks reports", func() {
+			BeforeEach(func() {
+				data.Webhooks = []Webhook{
+					{
+						Name:             "vtestkind.example.com",
+						Type:             "ValidatingAdmissionWebhook",
+						Created:          true,
+						Configurations:   []string{"vtestkind.example.com-abcde"},
+						Service:          "testns/test-service",
+						ReadyEndpoints:   1,
+						InvalidOperand:   "TestKind/example-opcap-invalid",
+						InvalidField:     "spec.size",
+						Rejection:        "rejected by webhook",
+						RejectionMessage: `admission webhook "vtestkind.example.com" denied the request`,
+						Passed:           true,
+					},
+					{Name: "ctestkind.example.com", Type: "ConversionWebhook", Missing: []string{"testkinds.example.com"}},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report per webhook", func() {
+					Expect(WebhooksJsonReport(&w, data)).To(Succeed())
+					lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+					Expect(lines).To(HaveLen(2))
+					Expect(lines[0]).To(MatchJSON(`{"package":"testpackage","webhook":"vtestkind.example.com","type":"ValidatingAdmissionWebhook","message":"passed","created":true,"configurations":["vtestkind.example.com-abcde"],"missing":[],"service":"testns/test-service","readyEndpoints":1,"invalidOperand":"TestKind/example-opcap-invalid","invalidField":"spec.size","rejection":"rejected by webhook","rejectionMessage":"admission webhook vtestkind.example.com denied the request"}`))
+					Expect(lines[1]).To(MatchJSON(`{"package":"testpackage","webhook":"ctestkind.example.com","type":"ConversionWebhook","message":"failed","created":false,"configurations":[],"missing":["testkinds.example.com"],"service":"","readyEndpoints":0,"invalidOperand":"","invalidField":"","rejection":"","rejectionMessage":""}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(WebhooksTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("ValidatingAdmissionWebhook vtestkind.example.com: passed"))
+					Expect(w.String()).To(ContainSubstring("service: testns/test-service (1 ready endpoints)"))
+					Expect(w.String()).To(ContainSubstring("invalid operand TestKind/example-opcap-invalid (spec.size broken): rejected by webhook"))
+					Expect(w.String()).To(ContainSubstring("created: no, missing testkinds.example.com"))
+				})
+			})
+		})
+		Context("ALM example validation reports", func() {
+			BeforeEach(func() {
+				data.AlmExampleValidation = []AlmExampleValidation{
+					{APIVersion: "example.com/v1", Kind: "testkind", Name: "testname", CRD: "testkinds.example.com"},
+					{APIVersion: "example.com/v1", Kind: "testkind", Name: "invalid", CRD: "testkinds.example.com", Errors: []string{"spec.unknown: unknown field", `spec.size: Invalid value: "large"`}},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report per example", func() {
+					Expect(AlmExampleValidationJsonReport(&w, data)).To(Succeed())
+					lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+					Expect(lines).To(HaveLen(2))
+					Expect(lines[0]).To(MatchJSON(`{"package":"testpackage","apiVersion":"example.com/v1","kind":"testkind","name":"testname","crd":"testkinds.example.com","message":"valid","errors":[]}`))
+					Expect(lines[1]).To(MatchJSON(`{"package":"testpackage","apiVersion":"example.com/v1","kind":"testkind","name":"invalid","crd":"testkinds.example.com","message":"invalid","errors":["spec.unknown: unknown field","spec.size: Invalid value: large"]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(AlmExampleValidationTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("testkind/testname (example.com/v1): valid"))
+					Expect(w.String()).To(ContainSubstring("testkind/invalid (example.com/v1): invalid\n  spec.unknown: unknown field"))
+				})
+			})
+		})
+		Context("CRD quality reports", func() {
+			BeforeEach(func() {
+				data.CRDQuality = []CRDQuality{
+					{
+						Name:               "testkinds.example.com",
+						Kind:               "TestKind",
+						Found:              true,
+						Versions:           []string{"v1alpha1", "v1"},
+						StoredVersions:     []string{"v1"},
+						DeprecatedVersions: []string{"v1alpha1"},
+						Conversion:         "None",
+						Structural:         true,
+						StatusSubresource:  true,
+						Fields:             2,
+						Findings:           []string{"serves 2 versions without a conversion webhook"},
+					},
+					{Name: "missings.example.com", Kind: "Missing", Findings: []string{"no matching CustomResourceDefinition"}},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report per CRD", func() {
+					Expect(CRDQualityJsonReport(&w, data)).To(Succeed())
+					lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+					Expect(lines).To(HaveLen(2))
+					Expect(lines[0]).To(MatchJSON(`{"package":"testpackage","crd":"testkinds.example.com","kind":"TestKind","message":"failed","found":true,"versions":["v1alpha1","v1"],"storedVersions":["v1"],"deprecatedVersions":["v1alpha1"],"conversion":"None","structural":true,"statusSubresource":true,"fields":2,"undescribedFields":[],"findings":["serves 2 versions without a conversion webhook"]}`))
+					Expect(lines[1]).To(MatchJSON(`{"package":"testpackage","crd":"missings.example.com","kind":"Missing","message":"failed","found":false,"versions":[],"storedVersions":[],"deprecatedVersions":[],"conversion":"","structural":false,"statusSubresource":false,"fields":0,"undescribedFields":[],"findings":["no matching CustomResourceDefinition"]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(CRDQualityTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("testkinds.example.com (TestKind): versions v1alpha1, v1 (deprecated v1alpha1), conversion None"))
+					Expect(w.String()).To(ContainSubstring("  serves 2 versions without a conversion webhook"))
+					Expect(w.String()).To(ContainSubstring("missings.example.com (Missing): not found"))
+				})
+			})
+		})
+		Context("Namespace admin reports", func() {
+			BeforeEach(func() {
+				data.NamespaceAdmin = []NamespaceAdmin{
+					{
+						Role: "edit",
+						User: "opcap-namespace-edit",
+						Kinds: []KindAccess{
+							{Kind: "testkind", Create: true, Read: true, Delete: false, Errors: []string{"delete forbidden"}},
+						},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(NamespaceAdminJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","message":"failed","role":"edit","user":"opcap-namespace-edit","kinds":[{"kind":"testkind","create":true,"read":true,"delete":false,"errors":["delete forbidden"]}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(NamespaceAdminTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("testkind: create allowed, read allowed, delete denied"))
+					Expect(w.String()).To(ContainSubstring("Result: %s", "Failed"))
+				})
+			})
+		})
+		Context("Watch scope reports", func() {
+			BeforeEach(func() {
+				data.WatchScope = WatchScope{
+					TargetNamespaces:   []string{"testns-targetns1"},
+					UnwatchedNamespace: "testns-unwatched",
+					Operands: []ScopedOperand{
+						{Kind: "testkind", Name: "testname", Namespace: "testns-targetns1", Watched: true, Reconciled: true},
+						{Kind: "testkind", Name: "testname", Namespace: "testns-unwatched", Reconciled: true},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(WatchScopeJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"level":"info","message":"failed","package":"testpackage","channel":"test","installmode":"AllNamespaces","targetNamespaces":["testns-targetns1"],"unwatchedNamespace":"testns-unwatched","operands":[{"kind":"testkind","name":"testname","namespace":"testns-targetns1","watched":true,"reconciled":true,"error":""},{"kind":"testkind","name":"testname","namespace":"testns-unwatched","watched":false,"reconciled":true,"error":""}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(WatchScopeTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("testns-targetns1/testkind/testname (watched): reconciled"))
+					Expect(w.String()).To(ContainSubstring("testns-unwatched/testkind/testname (unwatched): reconciled"))
+					Expect(w.String()).To(ContainSubstring("Result: %s", "Failed"))
+				})
+			})
+		})
+		Context("Operand deletion reports", func() {
+			BeforeEach(func() {
+				data.OperandDeletion = []OperandDeletion{
+					{Kind: "testkind", Name: "testname", Deleted: true, TimeToDelete: 3 * time.Second},
+					{Kind: "testkind", Name: "stuck", Finalizers: []string{"test.opcap.io/finalizer"}, ForcedFinalizerRemoval: true, Leftovers: []string{"Deployment/stuck"}},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandDeletionJsonReport(&w, data)).To(Succeed())
+					lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+					Expect(lines).To(HaveLen(2))
+					Expect(lines[0]).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"passed","deleted":true,"timeToDelete":"3s","forcedFinalizerRemoval":false,"finalizers":[],"leftovers":[]}`))
+					Expect(lines[1]).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"stuck","message":"failed","deleted":false,"timeToDelete":"0s","forcedFinalizerRemoval":true,"finalizers":["test.opcap.io/finalizer"],"leftovers":["Deployment/stuck"]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandDeletionTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Deletion: deleted after 3s"))
+					Expect(w.String()).To(ContainSubstring("Deletion: never finished terminating"))
+					Expect(w.String()).To(ContainSubstring("Finalizers Force Removed: test.opcap.io/finalizer"))
+				})
+			})
+		})
+		Context("Operand events reports", func() {
+			BeforeEach(func() {
+				data.OperandEvents = []OperandEvents{
+					{
+						Kind:           "testkind",
+						Name:           "testname",
+						Passed:         true,
+						OperatorEvents: 1,
+						Events:         []Event{{Type: "Normal", Reason: "Reconciled", Message: "reconciled", Source: "testkind-controller"}},
+						Types:          []EventCount{{Name: "Normal", Count: 1}},
+						Reasons:        []EventCount{{Name: "Reconciled", Count: 1}},
+					},
+					{
+						Kind:    "testkind",
+						Name:    "silent",
+						Events:  []Event{{Type: "Normal", Reason: "Created", Message: "created", Source: "operator-lifecycle-manager"}},
+						Types:   []EventCount{{Name: "Normal", Count: 1}},
+						Reasons: []EventCount{{Name: "Created", Count: 1}},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandEventsJsonReport(&w, data)).To(Succeed())
+					lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+					Expect(lines).To(HaveLen(2))
+					Expect(lines[0]).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"passed","operatorEvents":1,"types":{"Normal":1},"reasons":{"Reconciled":1},"events":[{"type":"Normal","reason":"Reconciled","message":"reconciled","source":"testkind-controller","creationTimestamp":"0001-01-01 00:00:00 +0000 UTC"}]}`))
+					Expect(lines[1]).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"silent","message":"failed","operatorEvents":0,"types":{"Normal":1},"reasons":{"Created":1},"events":[{"type":"Normal","reason":"Created","message":"created","source":"operator-lifecycle-manager","creationTimestamp":"0001-01-01 00:00:00 +0000 UTC"}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandEventsTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Message: passed, 1 of 1 events emitted by the operator"))
+					Expect(w.String()).To(ContainSubstring("Reasons: Reconciled (1)"))
+					Expect(w.String()).To(ContainSubstring("Message: failed, no events emitted by the operator"))
+				})
+			})
+		})
+		Context("Metrics endpoint reports", func() {
+			BeforeEach(func() {
+				data.MetricsEndpoint = []MetricsEndpoint{
+					{Service: "testns/metrics", Port: "http-metrics", Scheme: "http", Reachable: true, Families: []string{"workqueue_depth"}},
+					{Service: "testns/secure-metrics", Port: "https", Scheme: "https", Reachable: true, RequiresAuth: true},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(MetricsEndpointJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(Equal(`{"package":"testpackage","service":"testns/metrics","port":"http-metrics","scheme":"http","proxyForbidden":false,"reachable":true,"requiresAuth":false,"error":"","families":["workqueue_depth"]}` + "\n" +
+						`{"package":"testpackage","service":"testns/secure-metrics","port":"https","scheme":"https","proxyForbidden":false,"reachable":true,"requiresAuth":true,"error":"","families":[]}` + "\n"))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(MetricsEndpointTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Message: 1 metric families exposed"))
+					Expect(w.String()).To(ContainSubstring("Message: requires authentication"))
+				})
+			})
+			When("the service proxy is forbidden", func() {
+				BeforeEach(func() {
+					data.MetricsEndpoint = []MetricsEndpoint{{Service: "testns/metrics", Port: "http-metrics", Scheme: "http", ProxyForbidden: true}}
+				})
+				It("should not report the endpoint as requiring authentication", func() {
+					Expect(MetricsEndpointTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Message: not scraped, the audit user may not get services/proxy"))
+				})
+			})
+		})
+		Context("Monitoring reports", func() {
+			BeforeEach(func() {
+				data.Monitoring = Monitoring{
+					ServiceMonitors: []string{"testns/metrics"},
+					PrometheusRules: []string{"testns/rules"},
+					Alerts: []AlertRule{
+						{PrometheusRule: "testns/rules", Group: "test.rules", Alert: "TestDown", Findings: []string{"no severity label", "no runbook_url annotation"}},
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(MonitoringJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"level":"info","message":"failed","package":"testpackage","channel":"test","installmode":"AllNamespaces","serviceMonitors":["testns/metrics"],"podMonitors":[],"prometheusRules":["testns/rules"],"alerts":[{"prometheusRule":"testns/rules","group":"test.rules","alert":"TestDown","severity":"","runbookURL":"","findings":["no severity label","no runbook_url annotation"]}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(MonitoringTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("PodMonitors: none"))
+					Expect(w.String()).To(ContainSubstring("testns/rules test.rules/TestDown: no severity label, no runbook_url annotation"))
+					Expect(w.String()).To(ContainSubstring("Result: %s", "Failed"))
+				})
+			})
+		})
+		Context("Operand disruption reports", func() {
+			BeforeEach(func() {
+				data.OperandDisruption = []OperandDisruption{
+					{
+						Kind:  "testkind",
+						Name:  "testname",
+						Ready: true,
+						Evictions: []PodEviction{
+							{Pod: "first", Evicted: true},
+							{Pod: "second", Blocked: true},
+						},
+						PDBBlocked: true,
+						Recovered:  true,
+					},
+				}
+			})
+			When("generating a JSON report", func() {
+				It("should create a valid JSON report", func() {
+					Expect(OperandDisruptionJsonReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(MatchJSON(`{"package":"testpackage","Operand Kind":"testkind","Operand Name":"testname","message":"passed","pdbBlocked":true,"recovered":true,"availabilityDropped":false,"unavailable":"0s","evictions":[{"pod":"first","evicted":true,"blocked":false},{"pod":"second","evicted":false,"blocked":true}]}`))
+				})
+			})
+			When("generating a text report", func() {
+				It("should print a report", func() {
+					Expect(OperandDisruptionTextReport(&w, data)).To(Succeed())
+					Expect(w.String()).To(ContainSubstring("Message: passed, operand stayed ready"))
+					Expect(w.String()).To(ContainSubstring("Pod/first: evicted\n"))
+					Expect(w.String()).To(ContainSubstring("Pod/second: blocked by PodDisruptionBudget"))
+					Expect(w.String()).To(ContainSubstring("PodDisruptionBudget: blocked the drain"))
+					Expect(w.String()).To(ContainSubstring("Recovered: yes"))
+				})
+			})
+		})
 		Context("Operand reports", func() {
 			When("generating a JSON report", func() {
 				When("given successful data", func() {
@@ -122,6 +951,7 @@ var _ = Describe("Report", func() {
 				When("given no operands", func() {
 					BeforeEach(func() {
 						data.Operands = []unstructured.Unstructured{}
+						data.OperandCount = 0
 					})
 					It("should report failed", func() {
 						Expect(OperandInstallJsonReport(&w, data)).To(Succeed())
@@ -143,6 +973,7 @@ var _ = Describe("Report", func() {
 				When("given no operands", func() {
 					BeforeEach(func() {
 						data.Operands = []unstructured.Unstructured{}
+						data.OperandCount = 0
 					})
 					It("should report failed", func() {
 						Expect(OperandInstallTextReport(&w, data)).To(Succeed())
@@ -0,0 +1,22 @@
+package report
+
+const (
+	watchScopeTextReportTemplate = `
+Watch Scope Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ .OcpVersion }}
+Package Name: {{ .Subscription.Package }}
+Channel: {{ .Subscription.Channel }}
+Catalog Source: {{ .Subscription.CatalogSource }}
+Install Mode: {{ .Subscription.InstallModeType }}
+Target Namespaces: {{ range $index, $ns := .WatchScope.TargetNamespaces }}{{ if $index }}, {{ end }}{{ $ns }}{{ end }}
+Unwatched Namespace: {{ .WatchScope.UnwatchedNamespace }}
+Operands:
+{{ range .WatchScope.Operands }}  {{ .Namespace }}/{{ .Kind }}/{{ .Name }} ({{ if .Watched }}watched{{ else }}unwatched{{ end }}): {{ if .Error }}{{ .Error }}{{ else if .Reconciled }}reconciled{{ else }}not reconciled{{ end }}
+{{ else }}  none
+{{ end }}Result: {{ if .WatchScope.Passed }}Passed{{ else }}Failed{{ end }}
+-----------------------------------------
+`
+	watchScopeJsonReportTemplate = `{"level":"info","message":"{{ if .WatchScope.Passed }}passed{{ else }}failed{{ end }}","package":"{{ .Subscription.Package }}","channel":"{{ .Subscription.Channel }}","installmode":"{{ .Subscription.InstallModeType }}","targetNamespaces":[{{ range $index, $ns := .WatchScope.TargetNamespaces }}{{ if $index }},{{ end }}"{{ $ns }}"{{ end }}],"unwatchedNamespace":"{{ .WatchScope.UnwatchedNamespace }}","operands":[{{ range $index, $operand := .WatchScope.Operands }}{{ if $index }},{{ end }}{"kind":"{{ $operand.Kind }}","name":"{{ $operand.Name }}","namespace":"{{ $operand.Namespace }}","watched":{{ $operand.Watched }},"reconciled":{{ $operand.Reconciled }},"error":"{{ replace $operand.Error "\"" "" }}"}{{ end }}]}{{"\n"}}`
+)
@@ -0,0 +1,22 @@
+package report
+
+const (
+	webhooksTextReportTemplate = `
+{{ with $dot := . }}
+Webhooks Report
+-----------------------------------------
+Report Date: {{ now }}
+OpenShift Version: {{ $dot.OcpVersion }}
+Package Name: {{ $dot.Subscription.Package }}
+{{ range .Webhooks }}{{ .Type }} {{ .Name }}: {{ if .Passed }}passed{{ else }}failed{{ end }}
+  created: {{ if .Created }}{{ range $index, $configuration := .Configurations }}{{ if $index }}, {{ end }}{{ $configuration }}{{ end }}{{ else }}no{{ if .Missing }}, missing {{ range $index, $missing := .Missing }}{{ if $index }}, {{ end }}{{ $missing }}{{ end }}{{ end }}{{ end }}
+  service: {{ if .Service }}{{ .Service }} ({{ .ReadyEndpoints }} ready endpoints){{ else }}none{{ end }}
+{{ if .InvalidOperand }}  invalid operand {{ .InvalidOperand }} ({{ .InvalidField }} broken): {{ .Rejection }}{{ if .RejectionMessage }}
+    {{ .RejectionMessage }}{{ end }}
+{{ end }}{{ else }}No webhooks
+{{ end }}-----------------------------------------
+{{ end }}
+`
+
+	webhooksJsonReportTemplate = `{{ with $dot := . }}{{ range .Webhooks }}{"package":"{{ $dot.Subscription.Package }}","webhook":"{{ .Name }}","type":"{{ .Type }}","message":"{{ if .Passed }}passed{{ else }}failed{{ end }}","created":{{ .Created }},"configurations":[{{ range $index, $configuration := .Configurations }}{{ if $index }},{{ end }}"{{ $configuration }}"{{ end }}],"missing":[{{ range $index, $missing := .Missing }}{{ if $index }},{{ end }}"{{ $missing }}"{{ end }}],"service":"{{ .Service }}","readyEndpoints":{{ .ReadyEndpoints }},"invalidOperand":"{{ .InvalidOperand }}","invalidField":"{{ .InvalidField }}","rejection":"{{ .Rejection }}","rejectionMessage":"{{ replace .RejectionMessage "\"" "" }}"}{{"\n"}}{{ end }}{{ end }}`
+)